@@ -25,6 +25,23 @@ import (
 
 type AliasSpec struct {
 
+	// Configures progressive traffic shifting for the alias. When set, a change
+	// to FunctionVersion moves traffic from the previous version to the new one
+	// step by step instead of all at once.
+	//
+	// - Type
+	// Canary walks through the configured steps in order. Linear repeats its
+	// single step, adding the step weight on every interval.
+	//
+	// - Steps
+	// Each step has a weight (the fraction of traffic routed to the new version)
+	// and a bake interval in seconds to hold the step before moving on.
+	//
+	// - Alarms
+	// CloudWatch alarm names checked before the first step and watched during the
+	// shift. If any of them is in the ALARM state, traffic is routed back to the
+	// previous version and a RolledBack condition is raised.
+	DeploymentStrategy *AliasDeploymentStrategy `json:"deploymentStrategy,omitempty"`
 	// A description of the alias.
 	Description *string `json:"description,omitempty"`
 	// Configures options for asynchronous invocation on an alias.
@@ -63,8 +80,8 @@ type AliasSpec struct {
 	// The function version that the alias invokes.
 	//
 	// Regex Pattern: `^(\$LATEST(\.PUBLISHED)?|[0-9]+)$`
-	// +kubebuilder:validation:Required
-	FunctionVersion *string `json:"functionVersion"`
+	FunctionVersion    *string                                  `json:"functionVersion,omitempty"`
+	FunctionVersionRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"functionVersionRef,omitempty"`
 	// The name of the alias.
 	//
 	// Regex Pattern: `^(?!^[0-9]+$)([a-zA-Z0-9-_]+)$`
@@ -78,6 +95,15 @@ type AliasSpec struct {
 	// - ProvisionedConcurrentExecutions
 	// The amount of provisioned concurrency to allocate for the version or alias.
 	// Minimum value of 1 is required
+	//
+	// - Autoscaling
+	// Registers the provisioned concurrency with Application Auto Scaling
+	// between MinCapacity and MaxCapacity. When TargetUtilization is set, a
+	// target tracking policy keeps the provisioned concurrency utilization
+	// at that value. While autoscaling is configured,
+	// ProvisionedConcurrentExecutions is only used as the initial value.
+	// ScheduledActions change the MinCapacity and MaxCapacity range on a
+	// cron or at() schedule, in the given time zone.
 	ProvisionedConcurrencyConfig *PutProvisionedConcurrencyConfigInput `json:"provisionedConcurrencyConfig,omitempty"`
 	// The routing configuration (https://docs.aws.amazon.com/lambda/latest/dg/configuration-aliases.html#configuring-alias-routing)
 	// of the alias.
@@ -97,9 +123,21 @@ type AliasStatus struct {
 	// resource
 	// +kubebuilder:validation:Optional
 	Conditions []*ackv1alpha1.Condition `json:"conditions"`
+	// The progress of the latest traffic shift driven by the alias deployment
+	// strategy.
+	// +kubebuilder:validation:Optional
+	Deployment *AliasDeploymentStatus `json:"deployment,omitempty"`
+	// The provisioned concurrency currently in effect and the active scheduled
+	// action of its autoscaling configuration.
+	// +kubebuilder:validation:Optional
+	ProvisionedConcurrency *ProvisionedConcurrencyStatus `json:"provisionedConcurrency,omitempty"`
 	// A unique identifier that changes when you update the alias.
 	// +kubebuilder:validation:Optional
 	RevisionID *string `json:"revisionID,omitempty"`
+	// The last traffic shift that was rolled back because one of the watched
+	// alarms fired.
+	// +kubebuilder:validation:Optional
+	Rollback *AliasRollbackStatus `json:"rollback,omitempty"`
 }
 
 // Alias is the Schema for the Aliases API
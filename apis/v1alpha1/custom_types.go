@@ -0,0 +1,322 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package v1alpha1
+
+import (
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The types in this file have no counterpart in the Lambda API. They back
+// the custom fields declared in generator.yaml and are only understood by
+// the controller.
+
+type AliasDeploymentStrategyType string
+
+const (
+	AliasDeploymentStrategyType_Canary AliasDeploymentStrategyType = "Canary"
+	AliasDeploymentStrategyType_Linear AliasDeploymentStrategyType = "Linear"
+)
+
+// Progressive traffic shifting configuration of a Lambda function alias.
+// When the alias FunctionVersion changes, traffic is moved from the previous
+// version to the new one step by step using the alias routing configuration.
+//
+// A Canary strategy walks through Steps in order. A Linear strategy takes a
+// single step and repeats it, adding its weight on every interval, until all
+// traffic reaches the new version.
+//
+// When Alarms is set, the named CloudWatch alarms are watched while traffic
+// is shifting. If any of them enters the ALARM state the alias is routed back
+// to the previous version and the new version is recorded as failed.
+type AliasDeploymentStrategy struct {
+	Alarms []*string `json:"alarms,omitempty"`
+	// +kubebuilder:validation:Enum=Canary;Linear
+	// +kubebuilder:validation:Required
+	Type *string `json:"type"`
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:Required
+	Steps []*AliasDeploymentStep `json:"steps"`
+}
+
+// A single traffic shifting step. Weight is the fraction of traffic (between
+// 0.0 and 1.0, exclusive) routed to the new version, and BakeIntervalInSeconds
+// is how long the step is held before moving to the next one.
+type AliasDeploymentStep struct {
+	// +kubebuilder:validation:Required
+	Weight *float64 `json:"weight"`
+	// +kubebuilder:validation:Minimum=0
+	BakeIntervalInSeconds *int64 `json:"bakeIntervalInSeconds,omitempty"`
+}
+
+// Observed state of an in-progress or completed alias traffic shift.
+type AliasDeploymentStatus struct {
+	CurrentStep     *int64       `json:"currentStep,omitempty"`
+	CurrentWeight   *float64     `json:"currentWeight,omitempty"`
+	LastStepTime    *metav1.Time `json:"lastStepTime,omitempty"`
+	PreviousVersion *string      `json:"previousVersion,omitempty"`
+	TargetVersion   *string      `json:"targetVersion,omitempty"`
+}
+
+// Observed state of the last rolled back alias traffic shift. FailedVersion
+// isn't deployed again until FunctionVersion changes. FailedGeneration is the
+// last generation of the resource that still targeted FailedVersion.
+type AliasRollbackStatus struct {
+	Alarms           []*string    `json:"alarms,omitempty"`
+	FailedGeneration *int64       `json:"failedGeneration,omitempty"`
+	FailedVersion    *string      `json:"failedVersion,omitempty"`
+	PreviousVersion  *string      `json:"previousVersion,omitempty"`
+	RolledBackAt     *metav1.Time `json:"rolledBackAt,omitempty"`
+}
+
+// Application Auto Scaling configuration of the provisioned concurrency of a
+// function alias or version. The provisioned concurrency is registered as a
+// scalable target between MinCapacity and MaxCapacity. When TargetUtilization
+// is set, a target tracking policy on the
+// LambdaProvisionedConcurrencyUtilization metric is attached to it.
+//
+// While autoscaling is configured, the scaler owns the number of provisioned
+// concurrent executions. ProvisionedConcurrentExecutions is then only used as
+// the initial value.
+type ProvisionedConcurrencyAutoscaling struct {
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Required
+	MaxCapacity *int64 `json:"maxCapacity"`
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Required
+	MinCapacity *int64 `json:"minCapacity"`
+	// +kubebuilder:validation:Minimum=0.1
+	// +kubebuilder:validation:Maximum=0.9
+	TargetUtilization *float64                                 `json:"targetUtilization,omitempty"`
+	ScheduledActions  []*ProvisionedConcurrencyScheduledAction `json:"scheduledActions,omitempty"`
+}
+
+// A time based change of the provisioned concurrency scaling range. When the
+// schedule fires, the scalable target MinCapacity and MaxCapacity are set to
+// the values of the action and stay there until another action fires.
+//
+// Once an action fired, the scheduled actions own the scaling range and the
+// autoscaling MinCapacity and MaxCapacity are only used as the initial range.
+//
+// Schedule is an Application Auto Scaling schedule expression, for example
+// "cron(0 8 ? * MON-FRI *)" or "at(2026-12-24T18:00:00)". Timezone is an IANA
+// time zone name and defaults to UTC.
+type ProvisionedConcurrencyScheduledAction struct {
+	// +kubebuilder:validation:Required
+	Name *string `json:"name"`
+	// +kubebuilder:validation:Required
+	Schedule *string `json:"schedule"`
+	Timezone *string `json:"timezone,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	MaxCapacity *int64 `json:"maxCapacity,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	MinCapacity *int64 `json:"minCapacity,omitempty"`
+}
+
+// Observed provisioned concurrency of a function alias or version.
+//
+// ActiveScheduledAction is the scheduled action whose schedule fired last,
+// and ActiveSince the time it fired. CurrentMinCapacity and CurrentMaxCapacity
+// are the scaling range currently in effect.
+type ProvisionedConcurrencyStatus struct {
+	ActiveScheduledAction                    *string      `json:"activeScheduledAction,omitempty"`
+	ActiveSince                              *metav1.Time `json:"activeSince,omitempty"`
+	AllocatedProvisionedConcurrentExecutions *int64       `json:"allocatedProvisionedConcurrentExecutions,omitempty"`
+	CurrentMaxCapacity                       *int64       `json:"currentMaxCapacity,omitempty"`
+	CurrentMinCapacity                       *int64       `json:"currentMinCapacity,omitempty"`
+	Status                                   *string      `json:"status,omitempty"`
+}
+
+// The weight of an additional alias version given by reference. Set either
+// FunctionVersion or FunctionVersionRef, the latter resolving to the published
+// version number of a Version resource.
+type AliasVersionWeight struct {
+	FunctionVersion    *string                                  `json:"functionVersion,omitempty"`
+	FunctionVersionRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"functionVersionRef,omitempty"`
+	// +kubebuilder:validation:Required
+	Weight *float64 `json:"weight"`
+}
+
+// Retention policy of the published versions of a Lambda function. The
+// KeepLatest most recent versions are kept, older ones are deleted unless an
+// alias, an event source mapping, a function URL configuration or a Version
+// resource still references them.
+type FunctionVersionRetention struct {
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Required
+	KeepLatest *int64 `json:"keepLatest"`
+}
+
+// Observed state of the alias auto-published for a Lambda function.
+// PublishedVersions lists the most recent versions published for it, the
+// latest last.
+type FunctionAutoPublishStatus struct {
+	AliasVersion      *string                     `json:"aliasVersion,omitempty"`
+	PublishedVersions []*FunctionPublishedVersion `json:"publishedVersions,omitempty"`
+}
+
+// A version published for the auto-published alias of a Lambda function, and
+// the revision of $LATEST it was published from.
+type FunctionPublishedVersion struct {
+	CodeSHA256  *string      `json:"codeSHA256,omitempty"`
+	PublishedAt *metav1.Time `json:"publishedAt,omitempty"`
+	RevisionID  *string      `json:"revisionID,omitempty"`
+	Version     *string      `json:"version,omitempty"`
+}
+
+// Code and configuration of a Lambda function after its last successful
+// update. The .zip file archive location is only known when the function code
+// was deployed from Amazon S3.
+type FunctionSnapshot struct {
+	Architectures    []*string         `json:"architectures,omitempty"`
+	Code             *FunctionCode     `json:"code,omitempty"`
+	DeadLetterConfig *DeadLetterConfig `json:"deadLetterConfig,omitempty"`
+	Description      *string           `json:"description,omitempty"`
+	Environment      *Environment      `json:"environment,omitempty"`
+	EphemeralStorage *EphemeralStorage `json:"ephemeralStorage,omitempty"`
+	Handler          *string           `json:"handler,omitempty"`
+	KMSKeyARN        *string           `json:"kmsKeyARN,omitempty"`
+	Layers           []*string         `json:"layers,omitempty"`
+	MemorySize       *int64            `json:"memorySize,omitempty"`
+	RevisionID       *string           `json:"revisionID,omitempty"`
+	Role             *string           `json:"role,omitempty"`
+	Runtime          *string           `json:"runtime,omitempty"`
+	Timeout          *int64            `json:"timeout,omitempty"`
+	TracingConfig    *TracingConfig    `json:"tracingConfig,omitempty"`
+	VPCConfig        *VPCConfig        `json:"vpcConfig,omitempty"`
+}
+
+// Observed state of the automatic rollback of failed Lambda function updates.
+// FailedGeneration is the generation of the resource whose update failed and
+// was rolled back, it isn't applied again.
+type FunctionRollbackStatus struct {
+	FailedChanges    []*string         `json:"failedChanges,omitempty"`
+	FailedGeneration *int64            `json:"failedGeneration,omitempty"`
+	FailureReason    *string           `json:"failureReason,omitempty"`
+	LastKnownGood    *FunctionSnapshot `json:"lastKnownGood,omitempty"`
+	RolledBackAt     *metav1.Time      `json:"rolledBackAt,omitempty"`
+}
+
+// Smoke test of a Lambda function, invoked synchronously after each code
+// change. The payload is either set inline or read from a key of a ConfigMap
+// in the namespace of the Function. The invocation passes when the function
+// doesn't return an error and, when ResultAssertion is set, the result
+// matches it.
+type FunctionSmokeTest struct {
+	Payload         *string                         `json:"payload,omitempty"`
+	PayloadFrom     *FunctionSmokeTestPayloadSource `json:"payloadFrom,omitempty"`
+	ResultAssertion *FunctionSmokeTestAssertion     `json:"resultAssertion,omitempty"`
+}
+
+// A ConfigMap key holding the payload of a smoke test.
+type FunctionSmokeTestPayloadSource struct {
+	// +kubebuilder:validation:Required
+	ConfigMapName *string `json:"configMapName"`
+	// +kubebuilder:validation:Required
+	Key *string `json:"key"`
+}
+
+// An assertion on the result of a smoke test invocation. JSONPath is
+// evaluated against the JSON result, for example {.statusCode}. When Equals is
+// set, the evaluated value must be equal to it, otherwise it must not be
+// empty.
+type FunctionSmokeTestAssertion struct {
+	Equals *string `json:"equals,omitempty"`
+	// +kubebuilder:validation:Required
+	JSONPath *string `json:"jsonPath"`
+}
+
+// Outcome of the last smoke test invocation of a Lambda function. CodeSHA256
+// is the code the smoke test ran against, LogTail the last 4 KB of its
+// execution log.
+type FunctionSmokeTestStatus struct {
+	CodeSHA256 *string      `json:"codeSHA256,omitempty"`
+	LogTail    *string      `json:"logTail,omitempty"`
+	Message    *string      `json:"message,omitempty"`
+	Passed     *bool        `json:"passed,omitempty"`
+	RequestID  *string      `json:"requestID,omitempty"`
+	TestedAt   *metav1.Time `json:"testedAt,omitempty"`
+}
+
+// Observed state of the roll back of a Lambda function's $LATEST to a
+// published version. The code is restored first, then the configuration once
+// the code update finished. RestoredAt is set when both were restored.
+type FunctionRestoreStatus struct {
+	CodeSHA256            *string      `json:"codeSHA256,omitempty"`
+	ConfigurationRestored *bool        `json:"configurationRestored,omitempty"`
+	RestoredAt            *metav1.Time `json:"restoredAt,omitempty"`
+	Version               *string      `json:"version,omitempty"`
+}
+
+// A structured condition of an event filtering pattern, compiled by the
+// controller into the equivalent JSON pattern. Path is the dot-separated
+// location of the field in the event, for example body.status or
+// dynamodb.NewImage.quantity.N. Exactly one of Values, Prefix, Numeric,
+// Exists or AnythingBut is set.
+//
+// An event matches a filter when all of its rules match. Rules sharing the
+// same Path match when any of them does.
+type FilterRule struct {
+	// +kubebuilder:validation:Required
+	Path        *string             `json:"path"`
+	AnythingBut []*string           `json:"anythingBut,omitempty"`
+	Exists      *bool               `json:"exists,omitempty"`
+	Numeric     *FilterNumericRange `json:"numeric,omitempty"`
+	Prefix      *string             `json:"prefix,omitempty"`
+	Values      []*string           `json:"values,omitempty"`
+}
+
+// A numeric condition of a filter rule. Either Equals is set, or at most
+// one lower bound and one upper bound.
+type FilterNumericRange struct {
+	Equals             *float64 `json:"equals,omitempty"`
+	GreaterThan        *float64 `json:"greaterThan,omitempty"`
+	GreaterThanOrEqual *float64 `json:"greaterThanOrEqual,omitempty"`
+	LessThan           *float64 `json:"lessThan,omitempty"`
+	LessThanOrEqual    *float64 `json:"lessThanOrEqual,omitempty"`
+}
+
+type ScheduledInvocationConcurrencyPolicy string
+
+const (
+	ScheduledInvocationConcurrencyPolicy_Allow  ScheduledInvocationConcurrencyPolicy = "Allow"
+	ScheduledInvocationConcurrencyPolicy_Forbid ScheduledInvocationConcurrencyPolicy = "Forbid"
+)
+
+type ScheduledInvocationRunState string
+
+const (
+	ScheduledInvocationRunState_Running   ScheduledInvocationRunState = "Running"
+	ScheduledInvocationRunState_Succeeded ScheduledInvocationRunState = "Succeeded"
+	ScheduledInvocationRunState_Failed    ScheduledInvocationRunState = "Failed"
+	ScheduledInvocationRunState_Skipped   ScheduledInvocationRunState = "Skipped"
+	ScheduledInvocationRunState_Lost      ScheduledInvocationRunState = "Lost"
+)
+
+// A run of a scheduled invocation. Result is the function response, or an
+// error object, truncated to 1 KB. Runs are Skipped when the previous run was
+// still active and the concurrency policy is Forbid, and Lost when the
+// controller stopped tracking them, for example after a restart, and they
+// outlived the maximum duration of a function.
+type ScheduledInvocationRun struct {
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	FunctionError  *string      `json:"functionError,omitempty"`
+	Message        *string      `json:"message,omitempty"`
+	RequestID      *string      `json:"requestID,omitempty"`
+	Result         *string      `json:"result,omitempty"`
+	ScheduledTime  *metav1.Time `json:"scheduledTime,omitempty"`
+	StartTime      *metav1.Time `json:"startTime,omitempty"`
+	State          *string      `json:"state,omitempty"`
+	StatusCode     *int64       `json:"statusCode,omitempty"`
+}
@@ -53,6 +53,11 @@ type EventSourceMappingSpec struct {
 	// configuration object that specifies the destination of an event after Lambda
 	// processes it.
 	DestinationConfig *DestinationConfig `json:"destinationConfig,omitempty"`
+	// A reference to the DocumentDB cluster, managed by the ACK controller for
+	// Amazon DocumentDB, to read the change stream of. Sets the EventSourceARN.
+	DocumentDBClusterRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"documentDBClusterRef,omitempty"`
+	// Specific configuration settings for a DocumentDB event source.
+	DocumentDBEventSourceConfig *DocumentDBEventSourceConfig `json:"documentDBEventSourceConfig,omitempty"`
 	// When true, the event source mapping is active. When false, Lambda pauses
 	// polling and invocation.
 	//
@@ -102,6 +107,18 @@ type EventSourceMappingSpec struct {
 	// (Kinesis, DynamoDB Streams, Amazon MSK, self-managed Apache Kafka, and Amazon
 	// SQS) A list of current response type enums applied to the event source mapping.
 	FunctionResponseTypes []*string `json:"functionResponseTypes,omitempty"`
+	// The ARN of the Key Management Service (KMS) customer managed key that Lambda
+	// uses to encrypt your function's filter criteria (https://docs.aws.amazon.com/lambda/latest/dg/invocation-eventfiltering.html#filtering-basics).
+	// By default, Lambda does not encrypt your filter criteria object. Specify this
+	// property to encrypt data using your own customer managed key.
+	//
+	// Regex Pattern: `^(arn:(aws[a-zA-Z-]*)?:[a-z0-9-.]+:.*)|()$`
+	//
+	// The controller needs to be allowed to kms:Decrypt with the key through
+	// Lambda to read the filter criteria back. Otherwise changes to the filter criteria aren't
+	// detected and the FilterCriteriaHidden condition is raised.
+	KMSKeyARN *string                                  `json:"kmsKeyARN,omitempty"`
+	KMSKeyRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"kmsKeyRef,omitempty"`
 	// (Amazon MSK, and self-managed Apache Kafka only) The logging configuration
 	// for your event source. For more information, see Event source mapping logging
 	// (https://docs.aws.amazon.com/lambda/latest/dg/esm-logging.html).
@@ -130,10 +147,20 @@ type EventSourceMappingSpec struct {
 	// (-1). When set to infinite (-1), failed records are retried until the record
 	// expires.
 	MaximumRetryAttempts *int64 `json:"maximumRetryAttempts,omitempty"`
+	// The metrics configuration for your event source. For more information, see
+	// Event source mapping metrics (https://docs.aws.amazon.com/lambda/latest/dg/monitoring-metrics-types.html#event-source-mapping-metrics).
+	MetricsConfig *EventSourceMappingMetricsConfig `json:"metricsConfig,omitempty"`
 	// (Kinesis and DynamoDB Streams only) The number of batches to process from
 	// each shard concurrently.
-	ParallelizationFactor *int64                                     `json:"parallelizationFactor,omitempty"`
-	QueueRefs             []*ackv1alpha1.AWSResourceReferenceWrapper `json:"queueRefs,omitempty"`
+	ParallelizationFactor *int64 `json:"parallelizationFactor,omitempty"`
+	// (Amazon SQS, Amazon MSK, and self-managed Apache Kafka only) The provisioned
+	// mode configuration for the event source. For more information, see provisioned
+	// mode (https://docs.aws.amazon.com/lambda/latest/dg/invocation-eventsourcemapping.html#invocation-eventsourcemapping-provisioned-mode).
+	//
+	// The controller only supports it for Amazon MSK and self-managed Apache Kafka
+	// event sources.
+	ProvisionedPollerConfig *ProvisionedPollerConfig                   `json:"provisionedPollerConfig,omitempty"`
+	QueueRefs               []*ackv1alpha1.AWSResourceReferenceWrapper `json:"queueRefs,omitempty"`
 	// (MQ) The name of the Amazon MQ broker destination queue to consume.
 	Queues []*string `json:"queues,omitempty"`
 	// (Amazon SQS only) The scaling configuration for the event source. For more
@@ -178,6 +205,10 @@ type EventSourceMappingStatus struct {
 	// resource
 	// +kubebuilder:validation:Optional
 	Conditions []*ackv1alpha1.Condition `json:"conditions"`
+	// An object that contains details about an error related to filter criteria
+	// encryption.
+	// +kubebuilder:validation:Optional
+	FilterCriteriaError *FilterCriteriaError `json:"filterCriteriaError,omitempty"`
 	// The ARN of the Lambda function.
 	//
 	// Regex Pattern: `^arn:(aws[a-zA-Z-]*)?:lambda:[a-z]{2}((-gov)|(-iso([a-z]?)))?-[a-z]+-\d{1}:\d{12}:function:[a-zA-Z0-9-_]+(:(\$LATEST|[a-zA-Z0-9-_]+))?$`
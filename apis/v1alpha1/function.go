@@ -27,6 +27,10 @@ type FunctionSpec struct {
 	// array with one of the valid values (arm64 or x86_64). The default value is
 	// x86_64.
 	Architectures []*string `json:"architectures,omitempty"`
+	// The name of an alias to keep pointed at the latest code and configuration.
+	// Each successful code or configuration change publishes a new version and
+	// moves the alias to it. The alias is created if it doesn't exist.
+	AutoPublishAlias *string `json:"autoPublishAlias,omitempty"`
 	// The code for the function.
 	// +kubebuilder:validation:Required
 	Code *FunctionCode `json:"code"`
@@ -140,11 +144,21 @@ type FunctionSpec struct {
 	Publish *bool `json:"publish,omitempty"`
 	// The number of simultaneous executions to reserve for the function.
 	ReservedConcurrentExecutions *int64 `json:"reservedConcurrentExecutions,omitempty"`
+	// The published version to roll $LATEST back to. The code and configuration
+	// of the version are restored, and take precedence over Code and the
+	// function configuration of the spec until the field is removed.
+	RestoreFromVersion    *string                                  `json:"restoreFromVersion,omitempty"`
+	RestoreFromVersionRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"restoreFromVersionRef,omitempty"`
 	// The Amazon Resource Name (ARN) of the function's execution role.
 	//
 	// Regex Pattern: `^arn:(aws[a-zA-Z-]*)?:iam::\d{12}:role/?[a-zA-Z_0-9+=,.@\-_/]+$`
 	Role    *string                                  `json:"role,omitempty"`
 	RoleRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"roleRef,omitempty"`
+	// Roll the function back to the code and configuration of its last
+	// successful update when an update leaves it with a Failed
+	// LastUpdateStatus. The failed change isn't applied again until the spec
+	// changes.
+	RollbackOnFailure *bool `json:"rollbackOnFailure,omitempty"`
 	// The identifier of the function's runtime (https://docs.aws.amazon.com/lambda/latest/dg/lambda-runtimes.html).
 	// Runtime is required if the deployment package is a .zip file archive. Specifying
 	// a runtime results in an error if you're deploying a function using a container
@@ -156,6 +170,9 @@ type FunctionSpec struct {
 	//
 	// For a list of all currently supported runtimes, see Supported runtimes (https://docs.aws.amazon.com/lambda/latest/dg/lambda-runtimes.html#runtimes-supported).
 	Runtime *string `json:"runtime,omitempty"`
+	// Invokes the function synchronously with a test payload after each code
+	// change. The outcome is reported in the SmokeTestPassed condition.
+	SmokeTest *FunctionSmokeTest `json:"smokeTest,omitempty"`
 	// The function's SnapStart (https://docs.aws.amazon.com/lambda/latest/dg/snapstart.html)
 	// setting.
 	SnapStart *SnapStart `json:"snapStart,omitempty"`
@@ -180,6 +197,11 @@ type FunctionSpec struct {
 	// For more information, see Configuring a Lambda function to access resources
 	// in a VPC (https://docs.aws.amazon.com/lambda/latest/dg/configuration-vpc.html).
 	VPCConfig *VPCConfig `json:"vpcConfig,omitempty"`
+	// Retention policy of the published versions of the function. When set,
+	// versions beyond the KeepLatest most recent ones are deleted, unless an
+	// alias, an event source mapping, a function URL configuration or a
+	// Version resource still references them.
+	VersionRetention *FunctionVersionRetention `json:"versionRetention,omitempty"`
 }
 
 // FunctionStatus defines the observed state of Function
@@ -195,6 +217,10 @@ type FunctionStatus struct {
 	// resource
 	// +kubebuilder:validation:Optional
 	Conditions []*ackv1alpha1.Condition `json:"conditions"`
+	// The versions published for the auto-published alias and the version the
+	// alias points to.
+	// +kubebuilder:validation:Optional
+	AutoPublish *FunctionAutoPublishStatus `json:"autoPublish,omitempty"`
 	// The SHA256 hash of the function's deployment package.
 	// +kubebuilder:validation:Optional
 	CodeSHA256 *string `json:"codeSHA256,omitempty"`
@@ -221,14 +247,29 @@ type FunctionStatus struct {
 	// The function's layers (https://docs.aws.amazon.com/lambda/latest/dg/configuration-layers.html).
 	// +kubebuilder:validation:Optional
 	LayerStatuses []*Layer `json:"layerStatuses,omitempty"`
+	// Published versions of the function managed by a Version resource. They
+	// are never deleted by the retention policy.
+	// +kubebuilder:validation:Optional
+	ManagedVersions []*string `json:"managedVersions,omitempty"`
 	// For Lambda@Edge functions, the ARN of the main function.
 	//
 	// Regex Pattern: `^arn:(aws[a-zA-Z-]*)?:lambda:[a-z]{2}((-gov)|(-iso([a-z]?)))?-[a-z]+-\d{1}:\d{12}:function:[a-zA-Z0-9-_]+(:(\$LATEST|[a-zA-Z0-9-_]+))?$`
 	// +kubebuilder:validation:Optional
 	MasterARN *string `json:"masterARN,omitempty"`
+	// Published versions outside of the retention policy that are not
+	// referenced and are going to be deleted.
+	// +kubebuilder:validation:Optional
+	PrunableVersions []*string `json:"prunableVersions,omitempty"`
+	// The published version $LATEST was last rolled back to.
+	// +kubebuilder:validation:Optional
+	RestoredVersion *FunctionRestoreStatus `json:"restoredVersion,omitempty"`
 	// The latest updated revision of the function or alias.
 	// +kubebuilder:validation:Optional
 	RevisionID *string `json:"revisionID,omitempty"`
+	// The last known-good code and configuration of the function, and the
+	// last failed update that was rolled back.
+	// +kubebuilder:validation:Optional
+	Rollback *FunctionRollbackStatus `json:"rollback,omitempty"`
 	// The ARN of the signing job.
 	//
 	// Regex Pattern: `^arn:(aws[a-zA-Z0-9-]*):([a-zA-Z0-9\-])+:([a-z]{2}(-gov)?-[a-z]+-\d{1})?:(\d{12})?:(.*)$`
@@ -239,6 +280,9 @@ type FunctionStatus struct {
 	// Regex Pattern: `^arn:(aws[a-zA-Z0-9-]*):([a-zA-Z0-9\-])+:([a-z]{2}(-gov)?-[a-z]+-\d{1})?:(\d{12})?:(.*)$`
 	// +kubebuilder:validation:Optional
 	SigningProfileVersionARN *string `json:"signingProfileVersionARN,omitempty"`
+	// The outcome of the last smoke test invocation of the function.
+	// +kubebuilder:validation:Optional
+	SmokeTestResult *FunctionSmokeTestStatus `json:"smokeTestResult,omitempty"`
 	// The current state of the function. When the state is Inactive, you can reactivate
 	// the function by invoking it.
 	// +kubebuilder:validation:Optional
@@ -70,7 +70,8 @@ type AliasConfiguration struct {
 // The traffic-shifting (https://docs.aws.amazon.com/lambda/latest/dg/lambda-traffic-shifting-using-aliases.html)
 // configuration of a Lambda function alias.
 type AliasRoutingConfiguration struct {
-	AdditionalVersionWeights map[string]*float64 `json:"additionalVersionWeights,omitempty"`
+	AdditionalVersionWeightRefs []*AliasVersionWeight `json:"additionalVersionWeightRefs,omitempty"`
+	AdditionalVersionWeights    map[string]*float64   `json:"additionalVersionWeights,omitempty"`
 }
 
 // List of signing profiles that can sign a code package.
@@ -310,7 +311,8 @@ type FileSystemConfig struct {
 // A structure within a FilterCriteria object that defines an event filtering
 // pattern.
 type Filter struct {
-	Pattern *string `json:"pattern,omitempty"`
+	Pattern *string       `json:"pattern,omitempty"`
+	Rules   []*FilterRule `json:"rules,omitempty"`
 }
 
 // An object that contains the filters for an event source.
@@ -635,9 +637,10 @@ type PutFunctionEventInvokeConfigInput struct {
 }
 
 type PutProvisionedConcurrencyConfigInput struct {
-	FunctionName                    *string `json:"functionName,omitempty"`
-	ProvisionedConcurrentExecutions *int64  `json:"provisionedConcurrentExecutions,omitempty"`
-	Qualifier                       *string `json:"qualifier,omitempty"`
+	Autoscaling                     *ProvisionedConcurrencyAutoscaling `json:"autoscaling,omitempty"`
+	FunctionName                    *string                            `json:"functionName,omitempty"`
+	ProvisionedConcurrentExecutions *int64                             `json:"provisionedConcurrentExecutions,omitempty"`
+	Qualifier                       *string                            `json:"qualifier,omitempty"`
 }
 
 // The ARN of the runtime and any errors that occured.
@@ -692,6 +695,8 @@ type SnapStartResponse struct {
 type SourceAccessConfiguration struct {
 	Type *string `json:"type_,omitempty"`
 	URI  *string `json:"uRI,omitempty"`
+	// Reference field for URI
+	URIRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"uriRef,omitempty"`
 }
 
 // An object that contains details about an error related to retrieving tags.
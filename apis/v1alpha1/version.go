@@ -48,9 +48,28 @@ type VersionSpec struct {
 	// function name, it is limited to 64 characters in length.
 	//
 	// Regex Pattern: `^(arn:(aws[a-zA-Z-]*)?:lambda:)?([a-z]{2}((-gov)|(-iso([a-z]?)))?-[a-z]+-\d{1}:)?(\d{12}:)?(function:)?([a-zA-Z0-9-_]+)(:(\$LATEST|[a-zA-Z0-9-_]+))?$`
-	FunctionName                 *string                                  `json:"functionName,omitempty"`
-	FunctionRef                  *ackv1alpha1.AWSResourceReferenceWrapper `json:"functionRef,omitempty"`
-	ProvisionedConcurrencyConfig *PutProvisionedConcurrencyConfigInput    `json:"provisionedConcurrencyConfig,omitempty"`
+	FunctionName *string                                  `json:"functionName,omitempty"`
+	FunctionRef  *ackv1alpha1.AWSResourceReferenceWrapper `json:"functionRef,omitempty"`
+	// Configures provisioned concurrency to a function's version
+	//
+	// - ProvisionedConcurrentExecutions
+	// The amount of provisioned concurrency to allocate for the version or alias.
+	// Minimum value of 1 is required
+	//
+	// - Autoscaling
+	// Registers the provisioned concurrency with Application Auto Scaling
+	// between MinCapacity and MaxCapacity. When TargetUtilization is set, a
+	// target tracking policy keeps the provisioned concurrency utilization
+	// at that value. While autoscaling is configured,
+	// ProvisionedConcurrentExecutions is only used as the initial value.
+	// ScheduledActions change the MinCapacity and MaxCapacity range on a
+	// cron or at() schedule, in the given time zone.
+	ProvisionedConcurrencyConfig *PutProvisionedConcurrencyConfigInput `json:"provisionedConcurrencyConfig,omitempty"`
+	// Refuse to adopt an existing version when no changes were made to $LATEST
+	// since the last version was published. By default, the existing version
+	// is adopted as long as its code SHA256 and description match, and no other
+	// Version resource manages it.
+	RequireNewVersion *bool `json:"requireNewVersion,omitempty"`
 	// Only update the function if the revision ID matches the ID that's specified.
 	// Use this option to avoid publishing a version if the function configuration
 	// has changed since you last updated it.
@@ -163,6 +182,10 @@ type VersionStatus struct {
 	// Zip for .zip file archive.
 	// +kubebuilder:validation:Optional
 	PackageType *string `json:"packageType,omitempty"`
+	// The provisioned concurrency currently in effect and the active scheduled
+	// action of its autoscaling configuration.
+	// +kubebuilder:validation:Optional
+	ProvisionedConcurrency *ProvisionedConcurrencyStatus `json:"provisionedConcurrency,omitempty"`
 	// The version of the Lambda function.
 	//
 	// Regex Pattern: `^(\$LATEST|[0-9]+)$`
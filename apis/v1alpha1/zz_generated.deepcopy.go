@@ -216,6 +216,112 @@ func (in *AliasConfiguration) DeepCopy() *AliasConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AliasDeploymentStatus) DeepCopyInto(out *AliasDeploymentStatus) {
+	*out = *in
+	if in.CurrentStep != nil {
+		in, out := &in.CurrentStep, &out.CurrentStep
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CurrentWeight != nil {
+		in, out := &in.CurrentWeight, &out.CurrentWeight
+		*out = new(float64)
+		**out = **in
+	}
+	if in.LastStepTime != nil {
+		in, out := &in.LastStepTime, &out.LastStepTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PreviousVersion != nil {
+		in, out := &in.PreviousVersion, &out.PreviousVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.TargetVersion != nil {
+		in, out := &in.TargetVersion, &out.TargetVersion
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AliasDeploymentStatus.
+func (in *AliasDeploymentStatus) DeepCopy() *AliasDeploymentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AliasDeploymentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AliasDeploymentStep) DeepCopyInto(out *AliasDeploymentStep) {
+	*out = *in
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(float64)
+		**out = **in
+	}
+	if in.BakeIntervalInSeconds != nil {
+		in, out := &in.BakeIntervalInSeconds, &out.BakeIntervalInSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AliasDeploymentStep.
+func (in *AliasDeploymentStep) DeepCopy() *AliasDeploymentStep {
+	if in == nil {
+		return nil
+	}
+	out := new(AliasDeploymentStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AliasDeploymentStrategy) DeepCopyInto(out *AliasDeploymentStrategy) {
+	*out = *in
+	if in.Alarms != nil {
+		in, out := &in.Alarms, &out.Alarms
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(string)
+		**out = **in
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]*AliasDeploymentStep, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(AliasDeploymentStep)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AliasDeploymentStrategy.
+func (in *AliasDeploymentStrategy) DeepCopy() *AliasDeploymentStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(AliasDeploymentStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AliasList) DeepCopyInto(out *AliasList) {
 	*out = *in
@@ -248,9 +354,65 @@ func (in *AliasList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AliasRollbackStatus) DeepCopyInto(out *AliasRollbackStatus) {
+	*out = *in
+	if in.Alarms != nil {
+		in, out := &in.Alarms, &out.Alarms
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.FailedGeneration != nil {
+		in, out := &in.FailedGeneration, &out.FailedGeneration
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FailedVersion != nil {
+		in, out := &in.FailedVersion, &out.FailedVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.PreviousVersion != nil {
+		in, out := &in.PreviousVersion, &out.PreviousVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.RolledBackAt != nil {
+		in, out := &in.RolledBackAt, &out.RolledBackAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AliasRollbackStatus.
+func (in *AliasRollbackStatus) DeepCopy() *AliasRollbackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AliasRollbackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AliasRoutingConfiguration) DeepCopyInto(out *AliasRoutingConfiguration) {
 	*out = *in
+	if in.AdditionalVersionWeightRefs != nil {
+		in, out := &in.AdditionalVersionWeightRefs, &out.AdditionalVersionWeightRefs
+		*out = make([]*AliasVersionWeight, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(AliasVersionWeight)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	if in.AdditionalVersionWeights != nil {
 		in, out := &in.AdditionalVersionWeights, &out.AdditionalVersionWeights
 		*out = make(map[string]*float64, len(*in))
@@ -282,6 +444,11 @@ func (in *AliasRoutingConfiguration) DeepCopy() *AliasRoutingConfiguration {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AliasSpec) DeepCopyInto(out *AliasSpec) {
 	*out = *in
+	if in.DeploymentStrategy != nil {
+		in, out := &in.DeploymentStrategy, &out.DeploymentStrategy
+		*out = new(AliasDeploymentStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Description != nil {
 		in, out := &in.Description, &out.Description
 		*out = new(string)
@@ -307,6 +474,11 @@ func (in *AliasSpec) DeepCopyInto(out *AliasSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.FunctionVersionRef != nil {
+		in, out := &in.FunctionVersionRef, &out.FunctionVersionRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Name != nil {
 		in, out := &in.Name, &out.Name
 		*out = new(string)
@@ -364,11 +536,26 @@ func (in *AliasStatus) DeepCopyInto(out *AliasStatus) {
 			}
 		}
 	}
+	if in.Deployment != nil {
+		in, out := &in.Deployment, &out.Deployment
+		*out = new(AliasDeploymentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProvisionedConcurrency != nil {
+		in, out := &in.ProvisionedConcurrency, &out.ProvisionedConcurrency
+		*out = new(ProvisionedConcurrencyStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RevisionID != nil {
 		in, out := &in.RevisionID, &out.RevisionID
 		*out = new(string)
 		**out = **in
 	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(AliasRollbackStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AliasStatus.
@@ -381,6 +568,36 @@ func (in *AliasStatus) DeepCopy() *AliasStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AliasVersionWeight) DeepCopyInto(out *AliasVersionWeight) {
+	*out = *in
+	if in.FunctionVersion != nil {
+		in, out := &in.FunctionVersion, &out.FunctionVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.FunctionVersionRef != nil {
+		in, out := &in.FunctionVersionRef, &out.FunctionVersionRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(float64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AliasVersionWeight.
+func (in *AliasVersionWeight) DeepCopy() *AliasVersionWeight {
+	if in == nil {
+		return nil
+	}
+	out := new(AliasVersionWeight)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AllowedPublishers) DeepCopyInto(out *AllowedPublishers) {
 	*out = *in
@@ -1389,6 +1606,16 @@ func (in *EventSourceMappingSpec) DeepCopyInto(out *EventSourceMappingSpec) {
 		*out = new(DestinationConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DocumentDBClusterRef != nil {
+		in, out := &in.DocumentDBClusterRef, &out.DocumentDBClusterRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DocumentDBEventSourceConfig != nil {
+		in, out := &in.DocumentDBEventSourceConfig, &out.DocumentDBEventSourceConfig
+		*out = new(DocumentDBEventSourceConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Enabled != nil {
 		in, out := &in.Enabled, &out.Enabled
 		*out = new(bool)
@@ -1430,6 +1657,16 @@ func (in *EventSourceMappingSpec) DeepCopyInto(out *EventSourceMappingSpec) {
 			}
 		}
 	}
+	if in.KMSKeyARN != nil {
+		in, out := &in.KMSKeyARN, &out.KMSKeyARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.KMSKeyRef != nil {
+		in, out := &in.KMSKeyRef, &out.KMSKeyRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.LoggingConfig != nil {
 		in, out := &in.LoggingConfig, &out.LoggingConfig
 		*out = new(EventSourceMappingLoggingConfig)
@@ -1450,11 +1687,21 @@ func (in *EventSourceMappingSpec) DeepCopyInto(out *EventSourceMappingSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.MetricsConfig != nil {
+		in, out := &in.MetricsConfig, &out.MetricsConfig
+		*out = new(EventSourceMappingMetricsConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ParallelizationFactor != nil {
 		in, out := &in.ParallelizationFactor, &out.ParallelizationFactor
 		*out = new(int64)
 		**out = **in
 	}
+	if in.ProvisionedPollerConfig != nil {
+		in, out := &in.ProvisionedPollerConfig, &out.ProvisionedPollerConfig
+		*out = new(ProvisionedPollerConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.QueueRefs != nil {
 		in, out := &in.QueueRefs, &out.QueueRefs
 		*out = make([]*corev1alpha1.AWSResourceReferenceWrapper, len(*in))
@@ -1575,6 +1822,11 @@ func (in *EventSourceMappingStatus) DeepCopyInto(out *EventSourceMappingStatus)
 			}
 		}
 	}
+	if in.FilterCriteriaError != nil {
+		in, out := &in.FilterCriteriaError, &out.FilterCriteriaError
+		*out = new(FilterCriteriaError)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.FunctionARN != nil {
 		in, out := &in.FunctionARN, &out.FunctionARN
 		*out = new(string)
@@ -1669,6 +1921,17 @@ func (in *Filter) DeepCopyInto(out *Filter) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]*FilterRule, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(FilterRule)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Filter.
@@ -1732,6 +1995,103 @@ func (in *FilterCriteriaError) DeepCopy() *FilterCriteriaError {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilterNumericRange) DeepCopyInto(out *FilterNumericRange) {
+	*out = *in
+	if in.Equals != nil {
+		in, out := &in.Equals, &out.Equals
+		*out = new(float64)
+		**out = **in
+	}
+	if in.GreaterThan != nil {
+		in, out := &in.GreaterThan, &out.GreaterThan
+		*out = new(float64)
+		**out = **in
+	}
+	if in.GreaterThanOrEqual != nil {
+		in, out := &in.GreaterThanOrEqual, &out.GreaterThanOrEqual
+		*out = new(float64)
+		**out = **in
+	}
+	if in.LessThan != nil {
+		in, out := &in.LessThan, &out.LessThan
+		*out = new(float64)
+		**out = **in
+	}
+	if in.LessThanOrEqual != nil {
+		in, out := &in.LessThanOrEqual, &out.LessThanOrEqual
+		*out = new(float64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterNumericRange.
+func (in *FilterNumericRange) DeepCopy() *FilterNumericRange {
+	if in == nil {
+		return nil
+	}
+	out := new(FilterNumericRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilterRule) DeepCopyInto(out *FilterRule) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	if in.AnythingBut != nil {
+		in, out := &in.AnythingBut, &out.AnythingBut
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.Exists != nil {
+		in, out := &in.Exists, &out.Exists
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Numeric != nil {
+		in, out := &in.Numeric, &out.Numeric
+		*out = new(FilterNumericRange)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterRule.
+func (in *FilterRule) DeepCopy() *FilterRule {
+	if in == nil {
+		return nil
+	}
+	out := new(FilterRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Function) DeepCopyInto(out *Function) {
 	*out = *in
@@ -1760,15 +2120,46 @@ func (in *Function) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FunctionCode) DeepCopyInto(out *FunctionCode) {
+func (in *FunctionAutoPublishStatus) DeepCopyInto(out *FunctionAutoPublishStatus) {
 	*out = *in
-	if in.ImageURI != nil {
-		in, out := &in.ImageURI, &out.ImageURI
+	if in.AliasVersion != nil {
+		in, out := &in.AliasVersion, &out.AliasVersion
 		*out = new(string)
 		**out = **in
 	}
-	if in.S3Bucket != nil {
-		in, out := &in.S3Bucket, &out.S3Bucket
+	if in.PublishedVersions != nil {
+		in, out := &in.PublishedVersions, &out.PublishedVersions
+		*out = make([]*FunctionPublishedVersion, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(FunctionPublishedVersion)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionAutoPublishStatus.
+func (in *FunctionAutoPublishStatus) DeepCopy() *FunctionAutoPublishStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionAutoPublishStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FunctionCode) DeepCopyInto(out *FunctionCode) {
+	*out = *in
+	if in.ImageURI != nil {
+		in, out := &in.ImageURI, &out.ImageURI
+		*out = new(string)
+		**out = **in
+	}
+	if in.S3Bucket != nil {
+		in, out := &in.S3Bucket, &out.S3Bucket
 		*out = new(string)
 		**out = **in
 	}
@@ -2154,10 +2545,78 @@ func (in *FunctionList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FunctionSpec) DeepCopyInto(out *FunctionSpec) {
+func (in *FunctionPublishedVersion) DeepCopyInto(out *FunctionPublishedVersion) {
 	*out = *in
-	if in.Architectures != nil {
-		in, out := &in.Architectures, &out.Architectures
+	if in.CodeSHA256 != nil {
+		in, out := &in.CodeSHA256, &out.CodeSHA256
+		*out = new(string)
+		**out = **in
+	}
+	if in.PublishedAt != nil {
+		in, out := &in.PublishedAt, &out.PublishedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.RevisionID != nil {
+		in, out := &in.RevisionID, &out.RevisionID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionPublishedVersion.
+func (in *FunctionPublishedVersion) DeepCopy() *FunctionPublishedVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionPublishedVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FunctionRestoreStatus) DeepCopyInto(out *FunctionRestoreStatus) {
+	*out = *in
+	if in.CodeSHA256 != nil {
+		in, out := &in.CodeSHA256, &out.CodeSHA256
+		*out = new(string)
+		**out = **in
+	}
+	if in.ConfigurationRestored != nil {
+		in, out := &in.ConfigurationRestored, &out.ConfigurationRestored
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RestoredAt != nil {
+		in, out := &in.RestoredAt, &out.RestoredAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionRestoreStatus.
+func (in *FunctionRestoreStatus) DeepCopy() *FunctionRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FunctionRollbackStatus) DeepCopyInto(out *FunctionRollbackStatus) {
+	*out = *in
+	if in.FailedChanges != nil {
+		in, out := &in.FailedChanges, &out.FailedChanges
 		*out = make([]*string, len(*in))
 		for i := range *in {
 			if (*in)[i] != nil {
@@ -2167,19 +2626,178 @@ func (in *FunctionSpec) DeepCopyInto(out *FunctionSpec) {
 			}
 		}
 	}
-	if in.Code != nil {
-		in, out := &in.Code, &out.Code
-		*out = new(FunctionCode)
+	if in.FailedGeneration != nil {
+		in, out := &in.FailedGeneration, &out.FailedGeneration
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FailureReason != nil {
+		in, out := &in.FailureReason, &out.FailureReason
+		*out = new(string)
+		**out = **in
+	}
+	if in.LastKnownGood != nil {
+		in, out := &in.LastKnownGood, &out.LastKnownGood
+		*out = new(FunctionSnapshot)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.CodeSigningConfigARN != nil {
-		in, out := &in.CodeSigningConfigARN, &out.CodeSigningConfigARN
+	if in.RolledBackAt != nil {
+		in, out := &in.RolledBackAt, &out.RolledBackAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionRollbackStatus.
+func (in *FunctionRollbackStatus) DeepCopy() *FunctionRollbackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionRollbackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FunctionSmokeTest) DeepCopyInto(out *FunctionSmokeTest) {
+	*out = *in
+	if in.Payload != nil {
+		in, out := &in.Payload, &out.Payload
 		*out = new(string)
 		**out = **in
 	}
-	if in.CodeSigningConfigRef != nil {
-		in, out := &in.CodeSigningConfigRef, &out.CodeSigningConfigRef
-		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+	if in.PayloadFrom != nil {
+		in, out := &in.PayloadFrom, &out.PayloadFrom
+		*out = new(FunctionSmokeTestPayloadSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResultAssertion != nil {
+		in, out := &in.ResultAssertion, &out.ResultAssertion
+		*out = new(FunctionSmokeTestAssertion)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionSmokeTest.
+func (in *FunctionSmokeTest) DeepCopy() *FunctionSmokeTest {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionSmokeTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FunctionSmokeTestAssertion) DeepCopyInto(out *FunctionSmokeTestAssertion) {
+	*out = *in
+	if in.Equals != nil {
+		in, out := &in.Equals, &out.Equals
+		*out = new(string)
+		**out = **in
+	}
+	if in.JSONPath != nil {
+		in, out := &in.JSONPath, &out.JSONPath
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionSmokeTestAssertion.
+func (in *FunctionSmokeTestAssertion) DeepCopy() *FunctionSmokeTestAssertion {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionSmokeTestAssertion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FunctionSmokeTestPayloadSource) DeepCopyInto(out *FunctionSmokeTestPayloadSource) {
+	*out = *in
+	if in.ConfigMapName != nil {
+		in, out := &in.ConfigMapName, &out.ConfigMapName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionSmokeTestPayloadSource.
+func (in *FunctionSmokeTestPayloadSource) DeepCopy() *FunctionSmokeTestPayloadSource {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionSmokeTestPayloadSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FunctionSmokeTestStatus) DeepCopyInto(out *FunctionSmokeTestStatus) {
+	*out = *in
+	if in.CodeSHA256 != nil {
+		in, out := &in.CodeSHA256, &out.CodeSHA256
+		*out = new(string)
+		**out = **in
+	}
+	if in.LogTail != nil {
+		in, out := &in.LogTail, &out.LogTail
+		*out = new(string)
+		**out = **in
+	}
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+	if in.Passed != nil {
+		in, out := &in.Passed, &out.Passed
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RequestID != nil {
+		in, out := &in.RequestID, &out.RequestID
+		*out = new(string)
+		**out = **in
+	}
+	if in.TestedAt != nil {
+		in, out := &in.TestedAt, &out.TestedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionSmokeTestStatus.
+func (in *FunctionSmokeTestStatus) DeepCopy() *FunctionSmokeTestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionSmokeTestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FunctionSnapshot) DeepCopyInto(out *FunctionSnapshot) {
+	*out = *in
+	if in.Architectures != nil {
+		in, out := &in.Architectures, &out.Architectures
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.Code != nil {
+		in, out := &in.Code, &out.Code
+		*out = new(FunctionCode)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.DeadLetterConfig != nil {
@@ -2192,11 +2810,6 @@ func (in *FunctionSpec) DeepCopyInto(out *FunctionSpec) {
 		*out = new(string)
 		**out = **in
 	}
-	if in.DurableConfig != nil {
-		in, out := &in.DurableConfig, &out.DurableConfig
-		*out = new(DurableConfig)
-		(*in).DeepCopyInto(*out)
-	}
 	if in.Environment != nil {
 		in, out := &in.Environment, &out.Environment
 		*out = new(Environment)
@@ -2207,42 +2820,16 @@ func (in *FunctionSpec) DeepCopyInto(out *FunctionSpec) {
 		*out = new(EphemeralStorage)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.FileSystemConfigs != nil {
-		in, out := &in.FileSystemConfigs, &out.FileSystemConfigs
-		*out = make([]*FileSystemConfig, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(FileSystemConfig)
-				(*in).DeepCopyInto(*out)
-			}
-		}
-	}
-	if in.FunctionEventInvokeConfig != nil {
-		in, out := &in.FunctionEventInvokeConfig, &out.FunctionEventInvokeConfig
-		*out = new(PutFunctionEventInvokeConfigInput)
-		(*in).DeepCopyInto(*out)
-	}
 	if in.Handler != nil {
 		in, out := &in.Handler, &out.Handler
 		*out = new(string)
 		**out = **in
 	}
-	if in.ImageConfig != nil {
-		in, out := &in.ImageConfig, &out.ImageConfig
-		*out = new(ImageConfig)
-		(*in).DeepCopyInto(*out)
-	}
 	if in.KMSKeyARN != nil {
 		in, out := &in.KMSKeyARN, &out.KMSKeyARN
 		*out = new(string)
 		**out = **in
 	}
-	if in.KMSKeyRef != nil {
-		in, out := &in.KMSKeyRef, &out.KMSKeyRef
-		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
-		(*in).DeepCopyInto(*out)
-	}
 	if in.Layers != nil {
 		in, out := &in.Layers, &out.Layers
 		*out = make([]*string, len(*in))
@@ -2254,29 +2841,182 @@ func (in *FunctionSpec) DeepCopyInto(out *FunctionSpec) {
 			}
 		}
 	}
-	if in.LoggingConfig != nil {
-		in, out := &in.LoggingConfig, &out.LoggingConfig
-		*out = new(LoggingConfig)
-		(*in).DeepCopyInto(*out)
-	}
 	if in.MemorySize != nil {
 		in, out := &in.MemorySize, &out.MemorySize
 		*out = new(int64)
 		**out = **in
 	}
-	if in.Name != nil {
-		in, out := &in.Name, &out.Name
+	if in.RevisionID != nil {
+		in, out := &in.RevisionID, &out.RevisionID
 		*out = new(string)
 		**out = **in
 	}
-	if in.PackageType != nil {
-		in, out := &in.PackageType, &out.PackageType
+	if in.Role != nil {
+		in, out := &in.Role, &out.Role
 		*out = new(string)
 		**out = **in
 	}
-	if in.Publish != nil {
-		in, out := &in.Publish, &out.Publish
-		*out = new(bool)
+	if in.Runtime != nil {
+		in, out := &in.Runtime, &out.Runtime
+		*out = new(string)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TracingConfig != nil {
+		in, out := &in.TracingConfig, &out.TracingConfig
+		*out = new(TracingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VPCConfig != nil {
+		in, out := &in.VPCConfig, &out.VPCConfig
+		*out = new(VPCConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionSnapshot.
+func (in *FunctionSnapshot) DeepCopy() *FunctionSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FunctionSpec) DeepCopyInto(out *FunctionSpec) {
+	*out = *in
+	if in.Architectures != nil {
+		in, out := &in.Architectures, &out.Architectures
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.AutoPublishAlias != nil {
+		in, out := &in.AutoPublishAlias, &out.AutoPublishAlias
+		*out = new(string)
+		**out = **in
+	}
+	if in.Code != nil {
+		in, out := &in.Code, &out.Code
+		*out = new(FunctionCode)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CodeSigningConfigARN != nil {
+		in, out := &in.CodeSigningConfigARN, &out.CodeSigningConfigARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.CodeSigningConfigRef != nil {
+		in, out := &in.CodeSigningConfigRef, &out.CodeSigningConfigRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeadLetterConfig != nil {
+		in, out := &in.DeadLetterConfig, &out.DeadLetterConfig
+		*out = new(DeadLetterConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.DurableConfig != nil {
+		in, out := &in.DurableConfig, &out.DurableConfig
+		*out = new(DurableConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Environment != nil {
+		in, out := &in.Environment, &out.Environment
+		*out = new(Environment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EphemeralStorage != nil {
+		in, out := &in.EphemeralStorage, &out.EphemeralStorage
+		*out = new(EphemeralStorage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FileSystemConfigs != nil {
+		in, out := &in.FileSystemConfigs, &out.FileSystemConfigs
+		*out = make([]*FileSystemConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(FileSystemConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.FunctionEventInvokeConfig != nil {
+		in, out := &in.FunctionEventInvokeConfig, &out.FunctionEventInvokeConfig
+		*out = new(PutFunctionEventInvokeConfigInput)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Handler != nil {
+		in, out := &in.Handler, &out.Handler
+		*out = new(string)
+		**out = **in
+	}
+	if in.ImageConfig != nil {
+		in, out := &in.ImageConfig, &out.ImageConfig
+		*out = new(ImageConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KMSKeyARN != nil {
+		in, out := &in.KMSKeyARN, &out.KMSKeyARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.KMSKeyRef != nil {
+		in, out := &in.KMSKeyRef, &out.KMSKeyRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Layers != nil {
+		in, out := &in.Layers, &out.Layers
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.LoggingConfig != nil {
+		in, out := &in.LoggingConfig, &out.LoggingConfig
+		*out = new(LoggingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MemorySize != nil {
+		in, out := &in.MemorySize, &out.MemorySize
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.PackageType != nil {
+		in, out := &in.PackageType, &out.PackageType
+		*out = new(string)
+		**out = **in
+	}
+	if in.Publish != nil {
+		in, out := &in.Publish, &out.Publish
+		*out = new(bool)
 		**out = **in
 	}
 	if in.ReservedConcurrentExecutions != nil {
@@ -2284,6 +3024,16 @@ func (in *FunctionSpec) DeepCopyInto(out *FunctionSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.RestoreFromVersion != nil {
+		in, out := &in.RestoreFromVersion, &out.RestoreFromVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.RestoreFromVersionRef != nil {
+		in, out := &in.RestoreFromVersionRef, &out.RestoreFromVersionRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Role != nil {
 		in, out := &in.Role, &out.Role
 		*out = new(string)
@@ -2294,11 +3044,21 @@ func (in *FunctionSpec) DeepCopyInto(out *FunctionSpec) {
 		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RollbackOnFailure != nil {
+		in, out := &in.RollbackOnFailure, &out.RollbackOnFailure
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Runtime != nil {
 		in, out := &in.Runtime, &out.Runtime
 		*out = new(string)
 		**out = **in
 	}
+	if in.SmokeTest != nil {
+		in, out := &in.SmokeTest, &out.SmokeTest
+		*out = new(FunctionSmokeTest)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SnapStart != nil {
 		in, out := &in.SnapStart, &out.SnapStart
 		*out = new(SnapStart)
@@ -2340,6 +3100,11 @@ func (in *FunctionSpec) DeepCopyInto(out *FunctionSpec) {
 		*out = new(VPCConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.VersionRetention != nil {
+		in, out := &in.VersionRetention, &out.VersionRetention
+		*out = new(FunctionVersionRetention)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionSpec.
@@ -2371,6 +3136,11 @@ func (in *FunctionStatus) DeepCopyInto(out *FunctionStatus) {
 			}
 		}
 	}
+	if in.AutoPublish != nil {
+		in, out := &in.AutoPublish, &out.AutoPublish
+		*out = new(FunctionAutoPublishStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CodeSHA256 != nil {
 		in, out := &in.CodeSHA256, &out.CodeSHA256
 		*out = new(string)
@@ -2417,16 +3187,48 @@ func (in *FunctionStatus) DeepCopyInto(out *FunctionStatus) {
 			}
 		}
 	}
+	if in.ManagedVersions != nil {
+		in, out := &in.ManagedVersions, &out.ManagedVersions
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
 	if in.MasterARN != nil {
 		in, out := &in.MasterARN, &out.MasterARN
 		*out = new(string)
 		**out = **in
 	}
+	if in.PrunableVersions != nil {
+		in, out := &in.PrunableVersions, &out.PrunableVersions
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.RestoredVersion != nil {
+		in, out := &in.RestoredVersion, &out.RestoredVersion
+		*out = new(FunctionRestoreStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RevisionID != nil {
 		in, out := &in.RevisionID, &out.RevisionID
 		*out = new(string)
 		**out = **in
 	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(FunctionRollbackStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SigningJobARN != nil {
 		in, out := &in.SigningJobARN, &out.SigningJobARN
 		*out = new(string)
@@ -2437,6 +3239,11 @@ func (in *FunctionStatus) DeepCopyInto(out *FunctionStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.SmokeTestResult != nil {
+		in, out := &in.SmokeTestResult, &out.SmokeTestResult
+		*out = new(FunctionSmokeTestStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.State != nil {
 		in, out := &in.State, &out.State
 		*out = new(string)
@@ -2664,6 +3471,26 @@ func (in *FunctionURLConfig_SDK) DeepCopy() *FunctionURLConfig_SDK {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FunctionVersionRetention) DeepCopyInto(out *FunctionVersionRetention) {
+	*out = *in
+	if in.KeepLatest != nil {
+		in, out := &in.KeepLatest, &out.KeepLatest
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionVersionRetention.
+func (in *FunctionVersionRetention) DeepCopy() *FunctionVersionRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionVersionRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FunctionVersionsByCapacityProviderListItem) DeepCopyInto(out *FunctionVersionsByCapacityProviderListItem) {
 	*out = *in
@@ -2807,6 +3634,33 @@ func (in *InstanceRequirements) DeepCopy() *InstanceRequirements {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Invocation) DeepCopyInto(out *Invocation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Invocation.
+func (in *Invocation) DeepCopy() *Invocation {
+	if in == nil {
+		return nil
+	}
+	out := new(Invocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Invocation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InvocationCompletedDetails) DeepCopyInto(out *InvocationCompletedDetails) {
 	*out = *in
@@ -2828,30 +3682,206 @@ func (in *InvocationCompletedDetails) DeepCopy() *InvocationCompletedDetails {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InvokeResponseStreamUpdate) DeepCopyInto(out *InvokeResponseStreamUpdate) {
+func (in *InvocationList) DeepCopyInto(out *InvocationList) {
 	*out = *in
-	if in.Payload != nil {
-		in, out := &in.Payload, &out.Payload
-		*out = make([]byte, len(*in))
-		copy(*out, *in)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Invocation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InvokeResponseStreamUpdate.
-func (in *InvokeResponseStreamUpdate) DeepCopy() *InvokeResponseStreamUpdate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InvocationList.
+func (in *InvocationList) DeepCopy() *InvocationList {
 	if in == nil {
 		return nil
 	}
-	out := new(InvokeResponseStreamUpdate)
+	out := new(InvocationList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InvocationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InvokeWithResponseStreamCompleteEvent) DeepCopyInto(out *InvokeWithResponseStreamCompleteEvent) {
+func (in *InvocationSpec) DeepCopyInto(out *InvocationSpec) {
 	*out = *in
-	if in.ErrorCode != nil {
-		in, out := &in.ErrorCode, &out.ErrorCode
+	if in.AliasRef != nil {
+		in, out := &in.AliasRef, &out.AliasRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BackoffLimit != nil {
+		in, out := &in.BackoffLimit, &out.BackoffLimit
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ClientContext != nil {
+		in, out := &in.ClientContext, &out.ClientContext
+		*out = new(string)
+		**out = **in
+	}
+	if in.FunctionName != nil {
+		in, out := &in.FunctionName, &out.FunctionName
+		*out = new(string)
+		**out = **in
+	}
+	if in.FunctionRef != nil {
+		in, out := &in.FunctionRef, &out.FunctionRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InvocationType != nil {
+		in, out := &in.InvocationType, &out.InvocationType
+		*out = new(string)
+		**out = **in
+	}
+	if in.LogType != nil {
+		in, out := &in.LogType, &out.LogType
+		*out = new(string)
+		**out = **in
+	}
+	if in.Payload != nil {
+		in, out := &in.Payload, &out.Payload
+		*out = new(string)
+		**out = **in
+	}
+	if in.Qualifier != nil {
+		in, out := &in.Qualifier, &out.Qualifier
+		*out = new(string)
+		**out = **in
+	}
+	if in.QualifierRef != nil {
+		in, out := &in.QualifierRef, &out.QualifierRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InvocationSpec.
+func (in *InvocationSpec) DeepCopy() *InvocationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InvocationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InvocationStatus) DeepCopyInto(out *InvocationStatus) {
+	*out = *in
+	if in.ACKResourceMetadata != nil {
+		in, out := &in.ACKResourceMetadata, &out.ACKResourceMetadata
+		*out = new(corev1alpha1.ResourceMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]*corev1alpha1.Condition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(corev1alpha1.Condition)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Attempts != nil {
+		in, out := &in.Attempts, &out.Attempts
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CompletedGeneration != nil {
+		in, out := &in.CompletedGeneration, &out.CompletedGeneration
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ExecutedVersion != nil {
+		in, out := &in.ExecutedVersion, &out.ExecutedVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.FunctionError != nil {
+		in, out := &in.FunctionError, &out.FunctionError
+		*out = new(string)
+		**out = **in
+	}
+	if in.LastAttemptTime != nil {
+		in, out := &in.LastAttemptTime, &out.LastAttemptTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LogResult != nil {
+		in, out := &in.LogResult, &out.LogResult
+		*out = new(string)
+		**out = **in
+	}
+	if in.ResultPayload != nil {
+		in, out := &in.ResultPayload, &out.ResultPayload
+		*out = new(string)
+		**out = **in
+	}
+	if in.State != nil {
+		in, out := &in.State, &out.State
+		*out = new(string)
+		**out = **in
+	}
+	if in.StatusCode != nil {
+		in, out := &in.StatusCode, &out.StatusCode
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InvocationStatus.
+func (in *InvocationStatus) DeepCopy() *InvocationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InvocationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InvokeResponseStreamUpdate) DeepCopyInto(out *InvokeResponseStreamUpdate) {
+	*out = *in
+	if in.Payload != nil {
+		in, out := &in.Payload, &out.Payload
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InvokeResponseStreamUpdate.
+func (in *InvokeResponseStreamUpdate) DeepCopy() *InvokeResponseStreamUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(InvokeResponseStreamUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InvokeWithResponseStreamCompleteEvent) DeepCopyInto(out *InvokeWithResponseStreamCompleteEvent) {
+	*out = *in
+	if in.ErrorCode != nil {
+		in, out := &in.ErrorCode, &out.ErrorCode
 		*out = new(string)
 		**out = **in
 	}
@@ -3438,147 +4468,732 @@ func (in *OnSuccess) DeepCopy() *OnSuccess {
 	if in == nil {
 		return nil
 	}
-	out := new(OnSuccess)
+	out := new(OnSuccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisionedConcurrencyAutoscaling) DeepCopyInto(out *ProvisionedConcurrencyAutoscaling) {
+	*out = *in
+	if in.MaxCapacity != nil {
+		in, out := &in.MaxCapacity, &out.MaxCapacity
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MinCapacity != nil {
+		in, out := &in.MinCapacity, &out.MinCapacity
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TargetUtilization != nil {
+		in, out := &in.TargetUtilization, &out.TargetUtilization
+		*out = new(float64)
+		**out = **in
+	}
+	if in.ScheduledActions != nil {
+		in, out := &in.ScheduledActions, &out.ScheduledActions
+		*out = make([]*ProvisionedConcurrencyScheduledAction, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ProvisionedConcurrencyScheduledAction)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionedConcurrencyAutoscaling.
+func (in *ProvisionedConcurrencyAutoscaling) DeepCopy() *ProvisionedConcurrencyAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisionedConcurrencyAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisionedConcurrencyConfigListItem) DeepCopyInto(out *ProvisionedConcurrencyConfigListItem) {
+	*out = *in
+	if in.FunctionARN != nil {
+		in, out := &in.FunctionARN, &out.FunctionARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.LastModified != nil {
+		in, out := &in.LastModified, &out.LastModified
+		*out = new(string)
+		**out = **in
+	}
+	if in.RequestedProvisionedConcurrentExecutions != nil {
+		in, out := &in.RequestedProvisionedConcurrentExecutions, &out.RequestedProvisionedConcurrentExecutions
+		*out = new(int64)
+		**out = **in
+	}
+	if in.StatusReason != nil {
+		in, out := &in.StatusReason, &out.StatusReason
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionedConcurrencyConfigListItem.
+func (in *ProvisionedConcurrencyConfigListItem) DeepCopy() *ProvisionedConcurrencyConfigListItem {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisionedConcurrencyConfigListItem)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisionedConcurrencyScheduledAction) DeepCopyInto(out *ProvisionedConcurrencyScheduledAction) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(string)
+		**out = **in
+	}
+	if in.Timezone != nil {
+		in, out := &in.Timezone, &out.Timezone
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxCapacity != nil {
+		in, out := &in.MaxCapacity, &out.MaxCapacity
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MinCapacity != nil {
+		in, out := &in.MinCapacity, &out.MinCapacity
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionedConcurrencyScheduledAction.
+func (in *ProvisionedConcurrencyScheduledAction) DeepCopy() *ProvisionedConcurrencyScheduledAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisionedConcurrencyScheduledAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisionedConcurrencyStatus) DeepCopyInto(out *ProvisionedConcurrencyStatus) {
+	*out = *in
+	if in.ActiveScheduledAction != nil {
+		in, out := &in.ActiveScheduledAction, &out.ActiveScheduledAction
+		*out = new(string)
+		**out = **in
+	}
+	if in.ActiveSince != nil {
+		in, out := &in.ActiveSince, &out.ActiveSince
+		*out = (*in).DeepCopy()
+	}
+	if in.AllocatedProvisionedConcurrentExecutions != nil {
+		in, out := &in.AllocatedProvisionedConcurrentExecutions, &out.AllocatedProvisionedConcurrentExecutions
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CurrentMaxCapacity != nil {
+		in, out := &in.CurrentMaxCapacity, &out.CurrentMaxCapacity
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CurrentMinCapacity != nil {
+		in, out := &in.CurrentMinCapacity, &out.CurrentMinCapacity
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionedConcurrencyStatus.
+func (in *ProvisionedConcurrencyStatus) DeepCopy() *ProvisionedConcurrencyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisionedConcurrencyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisionedPollerConfig) DeepCopyInto(out *ProvisionedPollerConfig) {
+	*out = *in
+	if in.MaximumPollers != nil {
+		in, out := &in.MaximumPollers, &out.MaximumPollers
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MinimumPollers != nil {
+		in, out := &in.MinimumPollers, &out.MinimumPollers
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PollerGroupName != nil {
+		in, out := &in.PollerGroupName, &out.PollerGroupName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionedPollerConfig.
+func (in *ProvisionedPollerConfig) DeepCopy() *ProvisionedPollerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisionedPollerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PutFunctionConcurrencyOutput) DeepCopyInto(out *PutFunctionConcurrencyOutput) {
+	*out = *in
+	if in.ReservedConcurrentExecutions != nil {
+		in, out := &in.ReservedConcurrentExecutions, &out.ReservedConcurrentExecutions
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PutFunctionConcurrencyOutput.
+func (in *PutFunctionConcurrencyOutput) DeepCopy() *PutFunctionConcurrencyOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(PutFunctionConcurrencyOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PutFunctionEventInvokeConfigInput) DeepCopyInto(out *PutFunctionEventInvokeConfigInput) {
+	*out = *in
+	if in.DestinationConfig != nil {
+		in, out := &in.DestinationConfig, &out.DestinationConfig
+		*out = new(DestinationConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FunctionName != nil {
+		in, out := &in.FunctionName, &out.FunctionName
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaximumEventAgeInSeconds != nil {
+		in, out := &in.MaximumEventAgeInSeconds, &out.MaximumEventAgeInSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaximumRetryAttempts != nil {
+		in, out := &in.MaximumRetryAttempts, &out.MaximumRetryAttempts
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Qualifier != nil {
+		in, out := &in.Qualifier, &out.Qualifier
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PutFunctionEventInvokeConfigInput.
+func (in *PutFunctionEventInvokeConfigInput) DeepCopy() *PutFunctionEventInvokeConfigInput {
+	if in == nil {
+		return nil
+	}
+	out := new(PutFunctionEventInvokeConfigInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PutProvisionedConcurrencyConfigInput) DeepCopyInto(out *PutProvisionedConcurrencyConfigInput) {
+	*out = *in
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(ProvisionedConcurrencyAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FunctionName != nil {
+		in, out := &in.FunctionName, &out.FunctionName
+		*out = new(string)
+		**out = **in
+	}
+	if in.ProvisionedConcurrentExecutions != nil {
+		in, out := &in.ProvisionedConcurrentExecutions, &out.ProvisionedConcurrentExecutions
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Qualifier != nil {
+		in, out := &in.Qualifier, &out.Qualifier
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PutProvisionedConcurrencyConfigInput.
+func (in *PutProvisionedConcurrencyConfigInput) DeepCopy() *PutProvisionedConcurrencyConfigInput {
+	if in == nil {
+		return nil
+	}
+	out := new(PutProvisionedConcurrencyConfigInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Redrive) DeepCopyInto(out *Redrive) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Redrive.
+func (in *Redrive) DeepCopy() *Redrive {
+	if in == nil {
+		return nil
+	}
+	out := new(Redrive)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Redrive) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedriveList) DeepCopyInto(out *RedriveList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Redrive, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedriveList.
+func (in *RedriveList) DeepCopy() *RedriveList {
+	if in == nil {
+		return nil
+	}
+	out := new(RedriveList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RedriveList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedriveSpec) DeepCopyInto(out *RedriveSpec) {
+	*out = *in
+	if in.BatchSize != nil {
+		in, out := &in.BatchSize, &out.BatchSize
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ClientContext != nil {
+		in, out := &in.ClientContext, &out.ClientContext
+		*out = new(string)
+		**out = **in
+	}
+	if in.FunctionName != nil {
+		in, out := &in.FunctionName, &out.FunctionName
+		*out = new(string)
+		**out = **in
+	}
+	if in.FunctionRef != nil {
+		in, out := &in.FunctionRef, &out.FunctionRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InvocationType != nil {
+		in, out := &in.InvocationType, &out.InvocationType
+		*out = new(string)
+		**out = **in
+	}
+	if in.LogType != nil {
+		in, out := &in.LogType, &out.LogType
+		*out = new(string)
+		**out = **in
+	}
+	if in.MessagesPerSecond != nil {
+		in, out := &in.MessagesPerSecond, &out.MessagesPerSecond
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Qualifier != nil {
+		in, out := &in.Qualifier, &out.Qualifier
+		*out = new(string)
+		**out = **in
+	}
+	if in.QueueARN != nil {
+		in, out := &in.QueueARN, &out.QueueARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.QueueRef != nil {
+		in, out := &in.QueueRef, &out.QueueRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedriveSpec.
+func (in *RedriveSpec) DeepCopy() *RedriveSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RedriveSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedriveStatus) DeepCopyInto(out *RedriveStatus) {
+	*out = *in
+	if in.ACKResourceMetadata != nil {
+		in, out := &in.ACKResourceMetadata, &out.ACKResourceMetadata
+		*out = new(corev1alpha1.ResourceMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]*corev1alpha1.Condition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(corev1alpha1.Condition)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ExecutedVersion != nil {
+		in, out := &in.ExecutedVersion, &out.ExecutedVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.FailedMessages != nil {
+		in, out := &in.FailedMessages, &out.FailedMessages
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FunctionError != nil {
+		in, out := &in.FunctionError, &out.FunctionError
+		*out = new(string)
+		**out = **in
+	}
+	if in.LastFailureMessage != nil {
+		in, out := &in.LastFailureMessage, &out.LastFailureMessage
+		*out = new(string)
+		**out = **in
+	}
+	if in.LogResult != nil {
+		in, out := &in.LogResult, &out.LogResult
+		*out = new(string)
+		**out = **in
+	}
+	if in.QueueURL != nil {
+		in, out := &in.QueueURL, &out.QueueURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.RedrivenMessages != nil {
+		in, out := &in.RedrivenMessages, &out.RedrivenMessages
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RemainingMessages != nil {
+		in, out := &in.RemainingMessages, &out.RemainingMessages
+		*out = new(int64)
+		**out = **in
+	}
+	if in.State != nil {
+		in, out := &in.State, &out.State
+		*out = new(string)
+		**out = **in
+	}
+	if in.StatusCode != nil {
+		in, out := &in.StatusCode, &out.StatusCode
+		*out = new(int64)
+		**out = **in
+	}
+	if in.UndeletedMessages != nil {
+		in, out := &in.UndeletedMessages, &out.UndeletedMessages
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedriveStatus.
+func (in *RedriveStatus) DeepCopy() *RedriveStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RedriveStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeVersionConfig) DeepCopyInto(out *RuntimeVersionConfig) {
+	*out = *in
+	if in.Error != nil {
+		in, out := &in.Error, &out.Error
+		*out = new(RuntimeVersionError)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RuntimeVersionARN != nil {
+		in, out := &in.RuntimeVersionARN, &out.RuntimeVersionARN
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeVersionConfig.
+func (in *RuntimeVersionConfig) DeepCopy() *RuntimeVersionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeVersionConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProvisionedConcurrencyConfigListItem) DeepCopyInto(out *ProvisionedConcurrencyConfigListItem) {
+func (in *RuntimeVersionError) DeepCopyInto(out *RuntimeVersionError) {
 	*out = *in
-	if in.FunctionARN != nil {
-		in, out := &in.FunctionARN, &out.FunctionARN
-		*out = new(string)
-		**out = **in
-	}
-	if in.LastModified != nil {
-		in, out := &in.LastModified, &out.LastModified
+	if in.ErrorCode != nil {
+		in, out := &in.ErrorCode, &out.ErrorCode
 		*out = new(string)
 		**out = **in
 	}
-	if in.RequestedProvisionedConcurrentExecutions != nil {
-		in, out := &in.RequestedProvisionedConcurrentExecutions, &out.RequestedProvisionedConcurrentExecutions
-		*out = new(int64)
-		**out = **in
-	}
-	if in.StatusReason != nil {
-		in, out := &in.StatusReason, &out.StatusReason
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
 		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionedConcurrencyConfigListItem.
-func (in *ProvisionedConcurrencyConfigListItem) DeepCopy() *ProvisionedConcurrencyConfigListItem {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeVersionError.
+func (in *RuntimeVersionError) DeepCopy() *RuntimeVersionError {
 	if in == nil {
 		return nil
 	}
-	out := new(ProvisionedConcurrencyConfigListItem)
+	out := new(RuntimeVersionError)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProvisionedPollerConfig) DeepCopyInto(out *ProvisionedPollerConfig) {
+func (in *ScalingConfig) DeepCopyInto(out *ScalingConfig) {
 	*out = *in
-	if in.MaximumPollers != nil {
-		in, out := &in.MaximumPollers, &out.MaximumPollers
-		*out = new(int64)
-		**out = **in
-	}
-	if in.MinimumPollers != nil {
-		in, out := &in.MinimumPollers, &out.MinimumPollers
+	if in.MaximumConcurrency != nil {
+		in, out := &in.MaximumConcurrency, &out.MaximumConcurrency
 		*out = new(int64)
 		**out = **in
 	}
-	if in.PollerGroupName != nil {
-		in, out := &in.PollerGroupName, &out.PollerGroupName
-		*out = new(string)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingConfig.
+func (in *ScalingConfig) DeepCopy() *ScalingConfig {
+	if in == nil {
+		return nil
 	}
+	out := new(ScalingConfig)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionedPollerConfig.
-func (in *ProvisionedPollerConfig) DeepCopy() *ProvisionedPollerConfig {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledInvocation) DeepCopyInto(out *ScheduledInvocation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledInvocation.
+func (in *ScheduledInvocation) DeepCopy() *ScheduledInvocation {
 	if in == nil {
 		return nil
 	}
-	out := new(ProvisionedPollerConfig)
+	out := new(ScheduledInvocation)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScheduledInvocation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PutFunctionConcurrencyOutput) DeepCopyInto(out *PutFunctionConcurrencyOutput) {
+func (in *ScheduledInvocationList) DeepCopyInto(out *ScheduledInvocationList) {
 	*out = *in
-	if in.ReservedConcurrentExecutions != nil {
-		in, out := &in.ReservedConcurrentExecutions, &out.ReservedConcurrentExecutions
-		*out = new(int64)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ScheduledInvocation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PutFunctionConcurrencyOutput.
-func (in *PutFunctionConcurrencyOutput) DeepCopy() *PutFunctionConcurrencyOutput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledInvocationList.
+func (in *ScheduledInvocationList) DeepCopy() *ScheduledInvocationList {
 	if in == nil {
 		return nil
 	}
-	out := new(PutFunctionConcurrencyOutput)
+	out := new(ScheduledInvocationList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScheduledInvocationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PutFunctionEventInvokeConfigInput) DeepCopyInto(out *PutFunctionEventInvokeConfigInput) {
+func (in *ScheduledInvocationRun) DeepCopyInto(out *ScheduledInvocationRun) {
 	*out = *in
-	if in.DestinationConfig != nil {
-		in, out := &in.DestinationConfig, &out.DestinationConfig
-		*out = new(DestinationConfig)
-		(*in).DeepCopyInto(*out)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
 	}
-	if in.FunctionName != nil {
-		in, out := &in.FunctionName, &out.FunctionName
+	if in.FunctionError != nil {
+		in, out := &in.FunctionError, &out.FunctionError
 		*out = new(string)
 		**out = **in
 	}
-	if in.MaximumEventAgeInSeconds != nil {
-		in, out := &in.MaximumEventAgeInSeconds, &out.MaximumEventAgeInSeconds
-		*out = new(int64)
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
 		**out = **in
 	}
-	if in.MaximumRetryAttempts != nil {
-		in, out := &in.MaximumRetryAttempts, &out.MaximumRetryAttempts
-		*out = new(int64)
+	if in.RequestID != nil {
+		in, out := &in.RequestID, &out.RequestID
+		*out = new(string)
 		**out = **in
 	}
-	if in.Qualifier != nil {
-		in, out := &in.Qualifier, &out.Qualifier
+	if in.Result != nil {
+		in, out := &in.Result, &out.Result
+		*out = new(string)
+		**out = **in
+	}
+	if in.ScheduledTime != nil {
+		in, out := &in.ScheduledTime, &out.ScheduledTime
+		*out = (*in).DeepCopy()
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.State != nil {
+		in, out := &in.State, &out.State
 		*out = new(string)
 		**out = **in
 	}
+	if in.StatusCode != nil {
+		in, out := &in.StatusCode, &out.StatusCode
+		*out = new(int64)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PutFunctionEventInvokeConfigInput.
-func (in *PutFunctionEventInvokeConfigInput) DeepCopy() *PutFunctionEventInvokeConfigInput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledInvocationRun.
+func (in *ScheduledInvocationRun) DeepCopy() *ScheduledInvocationRun {
 	if in == nil {
 		return nil
 	}
-	out := new(PutFunctionEventInvokeConfigInput)
+	out := new(ScheduledInvocationRun)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PutProvisionedConcurrencyConfigInput) DeepCopyInto(out *PutProvisionedConcurrencyConfigInput) {
+func (in *ScheduledInvocationSpec) DeepCopyInto(out *ScheduledInvocationSpec) {
 	*out = *in
+	if in.ClientContext != nil {
+		in, out := &in.ClientContext, &out.ClientContext
+		*out = new(string)
+		**out = **in
+	}
+	if in.ConcurrencyPolicy != nil {
+		in, out := &in.ConcurrencyPolicy, &out.ConcurrencyPolicy
+		*out = new(string)
+		**out = **in
+	}
 	if in.FunctionName != nil {
 		in, out := &in.FunctionName, &out.FunctionName
 		*out = new(string)
 		**out = **in
 	}
-	if in.ProvisionedConcurrentExecutions != nil {
-		in, out := &in.ProvisionedConcurrentExecutions, &out.ProvisionedConcurrentExecutions
-		*out = new(int64)
+	if in.FunctionRef != nil {
+		in, out := &in.FunctionRef, &out.FunctionRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InvocationType != nil {
+		in, out := &in.InvocationType, &out.InvocationType
+		*out = new(string)
+		**out = **in
+	}
+	if in.LogType != nil {
+		in, out := &in.LogType, &out.LogType
+		*out = new(string)
+		**out = **in
+	}
+	if in.Payload != nil {
+		in, out := &in.Payload, &out.Payload
+		*out = new(string)
 		**out = **in
 	}
 	if in.Qualifier != nil {
@@ -3586,84 +5201,114 @@ func (in *PutProvisionedConcurrencyConfigInput) DeepCopyInto(out *PutProvisioned
 		*out = new(string)
 		**out = **in
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PutProvisionedConcurrencyConfigInput.
-func (in *PutProvisionedConcurrencyConfigInput) DeepCopy() *PutProvisionedConcurrencyConfigInput {
-	if in == nil {
-		return nil
+	if in.RunHistoryLimit != nil {
+		in, out := &in.RunHistoryLimit, &out.RunHistoryLimit
+		*out = new(int64)
+		**out = **in
 	}
-	out := new(PutProvisionedConcurrencyConfigInput)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RuntimeVersionConfig) DeepCopyInto(out *RuntimeVersionConfig) {
-	*out = *in
-	if in.Error != nil {
-		in, out := &in.Error, &out.Error
-		*out = new(RuntimeVersionError)
-		(*in).DeepCopyInto(*out)
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(string)
+		**out = **in
 	}
-	if in.RuntimeVersionARN != nil {
-		in, out := &in.RuntimeVersionARN, &out.RuntimeVersionARN
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
 		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeVersionConfig.
-func (in *RuntimeVersionConfig) DeepCopy() *RuntimeVersionConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledInvocationSpec.
+func (in *ScheduledInvocationSpec) DeepCopy() *ScheduledInvocationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RuntimeVersionConfig)
+	out := new(ScheduledInvocationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RuntimeVersionError) DeepCopyInto(out *RuntimeVersionError) {
+func (in *ScheduledInvocationStatus) DeepCopyInto(out *ScheduledInvocationStatus) {
 	*out = *in
-	if in.ErrorCode != nil {
-		in, out := &in.ErrorCode, &out.ErrorCode
+	if in.ACKResourceMetadata != nil {
+		in, out := &in.ACKResourceMetadata, &out.ACKResourceMetadata
+		*out = new(corev1alpha1.ResourceMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]*corev1alpha1.Condition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(corev1alpha1.Condition)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.ActiveRuns != nil {
+		in, out := &in.ActiveRuns, &out.ActiveRuns
+		*out = make([]*ScheduledInvocationRun, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ScheduledInvocationRun)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.ExecutedVersion != nil {
+		in, out := &in.ExecutedVersion, &out.ExecutedVersion
 		*out = new(string)
 		**out = **in
 	}
-	if in.Message != nil {
-		in, out := &in.Message, &out.Message
+	if in.FunctionError != nil {
+		in, out := &in.FunctionError, &out.FunctionError
 		*out = new(string)
 		**out = **in
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeVersionError.
-func (in *RuntimeVersionError) DeepCopy() *RuntimeVersionError {
-	if in == nil {
-		return nil
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
 	}
-	out := new(RuntimeVersionError)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScalingConfig) DeepCopyInto(out *ScalingConfig) {
-	*out = *in
-	if in.MaximumConcurrency != nil {
-		in, out := &in.MaximumConcurrency, &out.MaximumConcurrency
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LogResult != nil {
+		in, out := &in.LogResult, &out.LogResult
+		*out = new(string)
+		**out = **in
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RecentRuns != nil {
+		in, out := &in.RecentRuns, &out.RecentRuns
+		*out = make([]*ScheduledInvocationRun, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ScheduledInvocationRun)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.StatusCode != nil {
+		in, out := &in.StatusCode, &out.StatusCode
 		*out = new(int64)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingConfig.
-func (in *ScalingConfig) DeepCopy() *ScalingConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledInvocationStatus.
+func (in *ScheduledInvocationStatus) DeepCopy() *ScheduledInvocationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ScalingConfig)
+	out := new(ScheduledInvocationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -3788,6 +5433,11 @@ func (in *SourceAccessConfiguration) DeepCopyInto(out *SourceAccessConfiguration
 		*out = new(string)
 		**out = **in
 	}
+	if in.URIRef != nil {
+		in, out := &in.URIRef, &out.URIRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceAccessConfiguration.
@@ -4083,6 +5733,11 @@ func (in *VersionSpec) DeepCopyInto(out *VersionSpec) {
 		*out = new(PutProvisionedConcurrencyConfigInput)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RequireNewVersion != nil {
+		in, out := &in.RequireNewVersion, &out.RequireNewVersion
+		*out = new(bool)
+		**out = **in
+	}
 	if in.RevisionID != nil {
 		in, out := &in.RevisionID, &out.RevisionID
 		*out = new(string)
@@ -4232,6 +5887,11 @@ func (in *VersionStatus) DeepCopyInto(out *VersionStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ProvisionedConcurrency != nil {
+		in, out := &in.ProvisionedConcurrency, &out.ProvisionedConcurrency
+		*out = new(ProvisionedConcurrencyStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Qualifier != nil {
 		in, out := &in.Qualifier, &out.Qualifier
 		*out = new(string)
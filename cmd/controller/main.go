@@ -49,10 +49,13 @@ import (
 	_ "github.com/aws-controllers-k8s/lambda-controller/pkg/resource/alias"
 	_ "github.com/aws-controllers-k8s/lambda-controller/pkg/resource/code_signing_config"
 	_ "github.com/aws-controllers-k8s/lambda-controller/pkg/resource/event_source_mapping"
-	_ "github.com/aws-controllers-k8s/lambda-controller/pkg/resource/function"
+	svcfunction "github.com/aws-controllers-k8s/lambda-controller/pkg/resource/function"
 	_ "github.com/aws-controllers-k8s/lambda-controller/pkg/resource/function_url_config"
+	_ "github.com/aws-controllers-k8s/lambda-controller/pkg/resource/invocation"
 	_ "github.com/aws-controllers-k8s/lambda-controller/pkg/resource/layer_version"
-	_ "github.com/aws-controllers-k8s/lambda-controller/pkg/resource/version"
+	_ "github.com/aws-controllers-k8s/lambda-controller/pkg/resource/redrive"
+	_ "github.com/aws-controllers-k8s/lambda-controller/pkg/resource/scheduled_invocation"
+	svcversion "github.com/aws-controllers-k8s/lambda-controller/pkg/resource/version"
 
 	"github.com/aws-controllers-k8s/lambda-controller/pkg/version"
 )
@@ -160,6 +163,21 @@ func main() {
 
 	stopChan := ctrlrt.SetupSignalHandler()
 
+	if err = svcfunction.SetupVersionIndex(stopChan, mgr); err != nil {
+		setupLog.Error(
+			err, "unable to index Version resources",
+			"aws.service", awsServiceAlias,
+		)
+		os.Exit(1)
+	}
+	if err = svcversion.SetupFunctionARNIndex(stopChan, mgr); err != nil {
+		setupLog.Error(
+			err, "unable to index Version resources",
+			"aws.service", awsServiceAlias,
+		)
+		os.Exit(1)
+	}
+
 	setupLog.Info(
 		"initializing service controller",
 		"aws.service", awsServiceAlias,
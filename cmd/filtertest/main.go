@@ -0,0 +1,167 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// filtertest evaluates the filter criteria of an EventSourceMapping manifest
+// against sample records, without calling AWS. It prints, for each record
+// of the JSON files in the records directory, whether Lambda would invoke
+// the function with it and which filters it matched.
+//
+//	go run ./cmd/filtertest --manifest esm.yaml --records ./samples
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	flag "github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+
+	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/lambda-controller/pkg/resource/eventfilter"
+)
+
+func main() {
+	manifest := flag.String("manifest", "", "path of the EventSourceMapping manifest")
+	records := flag.String("records", "", "directory of the sample record JSON files")
+	flag.Parse()
+	if *manifest == "" || *records == "" {
+		fmt.Fprintln(os.Stderr, "usage: filtertest --manifest <file> --records <directory>")
+		os.Exit(2)
+	}
+	if err := run(os.Stdout, *manifest, *records); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(out io.Writer, manifestPath string, recordsDir string) error {
+	patterns, err := loadPatterns(manifestPath)
+	if err != nil {
+		return err
+	}
+	files, err := filepath.Glob(filepath.Join(recordsDir, "*.json"))
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no .json files found in %s", recordsDir)
+	}
+	sort.Strings(files)
+
+	var passed, total int
+	for _, file := range files {
+		records, err := loadRecords(file)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		for i, record := range records {
+			matched, err := matchingFilters(patterns, eventfilter.FilterableRecord(record))
+			if err != nil {
+				return fmt.Errorf("%s: %w", file, err)
+			}
+			total++
+			name := fmt.Sprintf("%s#%d", filepath.Base(file), i)
+			// Without filter criteria Lambda invokes the function with
+			// every record.
+			if len(patterns) == 0 || len(matched) > 0 {
+				passed++
+				fmt.Fprintf(out, "PASS  %s%s\n", name, describe(matched))
+			} else {
+				fmt.Fprintf(out, "DROP  %s\n", name)
+			}
+		}
+	}
+	fmt.Fprintf(out, "\n%d of %d records pass the filter criteria\n", passed, total)
+	return nil
+}
+
+// loadPatterns returns the pattern of each filter of the manifest, compiling
+// the filters set with rules.
+func loadPatterns(path string) ([]string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	esm := &svcapitypes.EventSourceMapping{}
+	if err := yaml.UnmarshalStrict(b, esm); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if esm.Kind != "EventSourceMapping" {
+		return nil, fmt.Errorf("%s: expected an EventSourceMapping, got %q", path, esm.Kind)
+	}
+	if esm.Spec.FilterCriteria == nil {
+		return nil, nil
+	}
+	patterns := []string{}
+	for i, filter := range esm.Spec.FilterCriteria.Filters {
+		switch {
+		case filter == nil:
+			return nil, fmt.Errorf("spec.filterCriteria.filters[%d] is empty", i)
+		case filter.Rules != nil && filter.Pattern != nil:
+			return nil, fmt.Errorf("spec.filterCriteria.filters[%d]: pattern and rules can't both be set", i)
+		case filter.Rules != nil:
+			pattern, err := eventfilter.Compile(filter.Rules)
+			if err != nil {
+				return nil, fmt.Errorf("spec.filterCriteria.filters[%d].%w", i, err)
+			}
+			patterns = append(patterns, pattern)
+		case filter.Pattern != nil:
+			patterns = append(patterns, *filter.Pattern)
+		default:
+			return nil, fmt.Errorf("spec.filterCriteria.filters[%d] has neither a pattern nor rules", i)
+		}
+	}
+	return patterns, nil
+}
+
+func loadRecords(path string) ([]map[string]interface{}, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var document interface{}
+	if err := json.Unmarshal(b, &document); err != nil {
+		return nil, err
+	}
+	return eventfilter.Records(document)
+}
+
+func matchingFilters(patterns []string, record map[string]interface{}) ([]int, error) {
+	matched := []int{}
+	for i, pattern := range patterns {
+		ok, err := eventfilter.Match(pattern, record)
+		if err != nil {
+			return nil, fmt.Errorf("spec.filterCriteria.filters[%d]: %w", i, err)
+		}
+		if ok {
+			matched = append(matched, i)
+		}
+	}
+	return matched, nil
+}
+
+func describe(matched []int) string {
+	if len(matched) == 0 {
+		return ""
+	}
+	indexes := make([]string, 0, len(matched))
+	for _, i := range matched {
+		indexes = append(indexes, fmt.Sprint(i))
+	}
+	return "  filters: " + strings.Join(indexes, ",")
+}
@@ -69,6 +69,14 @@ rules:
   - get
   - list
   - watch
+- apiGroups:
+  - docdb.services.k8s.aws
+  resources:
+  - dbclusters
+  - dbclusters/status
+  verbs:
+  - get
+  - list
 - apiGroups:
   - ec2.services.k8s.aws
   resources:
@@ -111,7 +119,10 @@ rules:
   - eventsourcemappings
   - functions
   - functionurlconfigs
+  - invocations
   - layerversions
+  - redrives
+  - scheduledinvocations
   - versions
   verbs:
   - create
@@ -129,7 +140,10 @@ rules:
   - eventsourcemappings/status
   - functions/status
   - functionurlconfigs/status
+  - invocations/status
   - layerversions/status
+  - redrives/status
+  - scheduledinvocations/status
   - versions/status
   verbs:
   - get
@@ -181,6 +195,14 @@ rules:
   - get
   - patch
   - update
+- apiGroups:
+  - sqs.services.k8s.aws
+  resources:
+  - queues
+  - queues/status
+  verbs:
+  - get
+  - list
 {{- end }}
 
 {{/* Convert k/v map to string like: "key1=value1,key2=value2,..." */}}
@@ -125,6 +125,9 @@ func newResourceDelta(
 			delta.Add("Spec.FunctionVersion", a.ko.Spec.FunctionVersion, b.ko.Spec.FunctionVersion)
 		}
 	}
+	if !equality.Semantic.Equalities.DeepEqual(a.ko.Spec.FunctionVersionRef, b.ko.Spec.FunctionVersionRef) {
+		delta.Add("Spec.FunctionVersionRef", a.ko.Spec.FunctionVersionRef, b.ko.Spec.FunctionVersionRef)
+	}
 	if ackcompare.HasNilDifference(a.ko.Spec.Name, b.ko.Spec.Name) {
 		delta.Add("Spec.Name", a.ko.Spec.Name, b.ko.Spec.Name)
 	} else if a.ko.Spec.Name != nil && b.ko.Spec.Name != nil {
@@ -135,6 +138,38 @@ func newResourceDelta(
 	if ackcompare.HasNilDifference(a.ko.Spec.ProvisionedConcurrencyConfig, b.ko.Spec.ProvisionedConcurrencyConfig) {
 		delta.Add("Spec.ProvisionedConcurrencyConfig", a.ko.Spec.ProvisionedConcurrencyConfig, b.ko.Spec.ProvisionedConcurrencyConfig)
 	} else if a.ko.Spec.ProvisionedConcurrencyConfig != nil && b.ko.Spec.ProvisionedConcurrencyConfig != nil {
+		if ackcompare.HasNilDifference(a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling) {
+			delta.Add("Spec.ProvisionedConcurrencyConfig.Autoscaling", a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling)
+		} else if a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling != nil && b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling != nil {
+			if ackcompare.HasNilDifference(a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MaxCapacity, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MaxCapacity) {
+				delta.Add("Spec.ProvisionedConcurrencyConfig.Autoscaling.MaxCapacity", a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MaxCapacity, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MaxCapacity)
+			} else if a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MaxCapacity != nil && b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MaxCapacity != nil {
+				if *a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MaxCapacity != *b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MaxCapacity {
+					delta.Add("Spec.ProvisionedConcurrencyConfig.Autoscaling.MaxCapacity", a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MaxCapacity, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MaxCapacity)
+				}
+			}
+			if ackcompare.HasNilDifference(a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MinCapacity, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MinCapacity) {
+				delta.Add("Spec.ProvisionedConcurrencyConfig.Autoscaling.MinCapacity", a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MinCapacity, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MinCapacity)
+			} else if a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MinCapacity != nil && b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MinCapacity != nil {
+				if *a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MinCapacity != *b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MinCapacity {
+					delta.Add("Spec.ProvisionedConcurrencyConfig.Autoscaling.MinCapacity", a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MinCapacity, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MinCapacity)
+				}
+			}
+			if len(a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.ScheduledActions) != len(b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.ScheduledActions) {
+				delta.Add("Spec.ProvisionedConcurrencyConfig.Autoscaling.ScheduledActions", a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.ScheduledActions, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.ScheduledActions)
+			} else if len(a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.ScheduledActions) > 0 {
+				if !equality.Semantic.Equalities.DeepEqual(a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.ScheduledActions, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.ScheduledActions) {
+					delta.Add("Spec.ProvisionedConcurrencyConfig.Autoscaling.ScheduledActions", a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.ScheduledActions, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.ScheduledActions)
+				}
+			}
+			if ackcompare.HasNilDifference(a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.TargetUtilization, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.TargetUtilization) {
+				delta.Add("Spec.ProvisionedConcurrencyConfig.Autoscaling.TargetUtilization", a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.TargetUtilization, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.TargetUtilization)
+			} else if a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.TargetUtilization != nil && b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.TargetUtilization != nil {
+				if *a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.TargetUtilization != *b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.TargetUtilization {
+					delta.Add("Spec.ProvisionedConcurrencyConfig.Autoscaling.TargetUtilization", a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.TargetUtilization, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.TargetUtilization)
+				}
+			}
+		}
 		if ackcompare.HasNilDifference(a.ko.Spec.ProvisionedConcurrencyConfig.FunctionName, b.ko.Spec.ProvisionedConcurrencyConfig.FunctionName) {
 			delta.Add("Spec.ProvisionedConcurrencyConfig.FunctionName", a.ko.Spec.ProvisionedConcurrencyConfig.FunctionName, b.ko.Spec.ProvisionedConcurrencyConfig.FunctionName)
 		} else if a.ko.Spec.ProvisionedConcurrencyConfig.FunctionName != nil && b.ko.Spec.ProvisionedConcurrencyConfig.FunctionName != nil {
@@ -160,6 +195,13 @@ func newResourceDelta(
 	if ackcompare.HasNilDifference(a.ko.Spec.RoutingConfig, b.ko.Spec.RoutingConfig) {
 		delta.Add("Spec.RoutingConfig", a.ko.Spec.RoutingConfig, b.ko.Spec.RoutingConfig)
 	} else if a.ko.Spec.RoutingConfig != nil && b.ko.Spec.RoutingConfig != nil {
+		if len(a.ko.Spec.RoutingConfig.AdditionalVersionWeightRefs) != len(b.ko.Spec.RoutingConfig.AdditionalVersionWeightRefs) {
+			delta.Add("Spec.RoutingConfig.AdditionalVersionWeightRefs", a.ko.Spec.RoutingConfig.AdditionalVersionWeightRefs, b.ko.Spec.RoutingConfig.AdditionalVersionWeightRefs)
+		} else if len(a.ko.Spec.RoutingConfig.AdditionalVersionWeightRefs) > 0 {
+			if !equality.Semantic.Equalities.DeepEqual(a.ko.Spec.RoutingConfig.AdditionalVersionWeightRefs, b.ko.Spec.RoutingConfig.AdditionalVersionWeightRefs) {
+				delta.Add("Spec.RoutingConfig.AdditionalVersionWeightRefs", a.ko.Spec.RoutingConfig.AdditionalVersionWeightRefs, b.ko.Spec.RoutingConfig.AdditionalVersionWeightRefs)
+			}
+		}
 		if len(a.ko.Spec.RoutingConfig.AdditionalVersionWeights) != len(b.ko.Spec.RoutingConfig.AdditionalVersionWeights) {
 			delta.Add("Spec.RoutingConfig.AdditionalVersionWeights", a.ko.Spec.RoutingConfig.AdditionalVersionWeights, b.ko.Spec.RoutingConfig.AdditionalVersionWeights)
 		} else if len(a.ko.Spec.RoutingConfig.AdditionalVersionWeights) > 0 {
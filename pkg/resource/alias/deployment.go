@@ -0,0 +1,360 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package alias
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cloudwatchtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/lambda"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+)
+
+// ConditionTypeRolledBack is raised on an Alias whose traffic shift was
+// rolled back because one of the watched CloudWatch alarms fired.
+const ConditionTypeRolledBack ackv1alpha1.ConditionType = "RolledBack"
+
+var (
+	ErrTrafficShiftInProgress = errors.New("alias traffic shift in progress")
+)
+
+// requeueWaitWhileShiftingTraffic returns a requeue error that brings the
+// alias back once the current traffic shifting step has baked.
+func requeueWaitWhileShiftingTraffic(after time.Duration) *ackrequeue.RequeueNeededAfter {
+	return ackrequeue.NeededAfter(ErrTrafficShiftInProgress, after)
+}
+
+// deploymentSteps validates the supplied deployment strategy and returns the
+// ordered list of steps to walk through. Linear strategies are expanded from
+// their single step into as many steps as needed to reach the new version.
+func deploymentSteps(
+	strategy *svcapitypes.AliasDeploymentStrategy,
+) ([]*svcapitypes.AliasDeploymentStep, error) {
+	if strategy == nil || strategy.Type == nil {
+		return nil, errors.New("deployment strategy type is required")
+	}
+	if len(strategy.Steps) == 0 {
+		return nil, errors.New("deployment strategy requires at least one step")
+	}
+	for i, step := range strategy.Steps {
+		if step == nil || step.Weight == nil {
+			return nil, fmt.Errorf("deployment strategy step %d is missing a weight", i)
+		}
+		if *step.Weight <= 0 || *step.Weight >= 1 {
+			return nil, fmt.Errorf("deployment strategy step %d weight must be between 0.0 and 1.0, exclusive", i)
+		}
+	}
+
+	switch svcapitypes.AliasDeploymentStrategyType(*strategy.Type) {
+	case svcapitypes.AliasDeploymentStrategyType_Canary:
+		return strategy.Steps, nil
+	case svcapitypes.AliasDeploymentStrategyType_Linear:
+		if len(strategy.Steps) != 1 {
+			return nil, errors.New("linear deployment strategy takes exactly one step")
+		}
+		increment := *strategy.Steps[0].Weight
+		steps := []*svcapitypes.AliasDeploymentStep{}
+		for i := 1; ; i++ {
+			// Round to avoid floating point drift, e.g. 0.1*3 = 0.30000000000000004
+			weight := math.Round(increment*float64(i)*10000) / 10000
+			if weight >= 1 {
+				break
+			}
+			steps = append(steps, &svcapitypes.AliasDeploymentStep{
+				Weight:                aws.Float64(weight),
+				BakeIntervalInSeconds: strategy.Steps[0].BakeIntervalInSeconds,
+			})
+		}
+		return steps, nil
+	default:
+		return nil, fmt.Errorf("unsupported deployment strategy type %q", *strategy.Type)
+	}
+}
+
+// bakeInterval returns how long the supplied step should be held.
+func bakeInterval(step *svcapitypes.AliasDeploymentStep) time.Duration {
+	if step.BakeIntervalInSeconds == nil {
+		return 0
+	}
+	return time.Duration(*step.BakeIntervalInSeconds) * time.Second
+}
+
+// shiftTraffic moves the alias traffic from the version it currently points
+// to towards the desired FunctionVersion, one deployment strategy step at a
+// time. It returns a resource and a requeue error while steps are still
+// baking. Once every step has baked it returns nil, nil so that the caller
+// can promote the desired version with a regular UpdateAlias call.
+func (rm *resourceManager) shiftTraffic(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+) (shifted *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.shiftTraffic")
+	defer func() { exit(err) }()
+
+	if latest.ko.Spec.FunctionVersion == nil || desired.ko.Spec.FunctionVersion == nil {
+		return nil, nil
+	}
+	steps, err := deploymentSteps(desired.ko.Spec.DeploymentStrategy)
+	if err != nil {
+		return nil, ackerr.NewTerminalError(err)
+	}
+
+	updatedStatusResource := rm.concreteResource(desired.DeepCopy())
+	updatedStatusResource.SetStatus(latest)
+
+	previousVersion := *latest.ko.Spec.FunctionVersion
+	targetVersion := *desired.ko.Spec.FunctionVersion
+	now := metav1.Now()
+
+	deployment := desired.ko.Status.Deployment
+	if rollback := updatedStatusResource.ko.Status.Rollback; rollback != nil && stringPtrEquals(rollback.FailedVersion, &targetVersion) {
+		// The failed version is still desired by the new generation.
+		rollback.FailedGeneration = aws.Int64(desired.ko.Generation)
+		setRolledBackCondition(updatedStatusResource.ko)
+		msg := fmt.Sprintf("traffic shift to version %s was rolled back, set a different FunctionVersion to deploy again", targetVersion)
+		return updatedStatusResource, ackerr.NewTerminalError(errors.New(msg))
+	}
+
+	// The watched alarms are checked before every step, including the first
+	// one, so that no traffic is shifted while one of them is firing.
+	var alarms []string
+	alarms, err = rm.alarmsInAlarmState(ctx, desired.ko.Spec.DeploymentStrategy.Alarms)
+	if err != nil {
+		return nil, err
+	}
+	if len(alarms) > 0 {
+		return rm.rollbackTraffic(ctx, updatedStatusResource, latest, previousVersion, targetVersion, alarms)
+	}
+
+	step := 0
+	// Resume the ongoing shift only if it is still moving traffic between
+	// the same two versions, otherwise start over from the first step.
+	if deployment != nil && deployment.CurrentStep != nil &&
+		deployment.LastStepTime != nil &&
+		stringPtrEquals(deployment.PreviousVersion, &previousVersion) &&
+		stringPtrEquals(deployment.TargetVersion, &targetVersion) {
+		step = int(*deployment.CurrentStep)
+		if step < len(steps) {
+			remaining := bakeInterval(steps[step]) - now.Sub(deployment.LastStepTime.Time)
+			if remaining > 0 {
+				return updatedStatusResource, requeueWaitWhileShiftingTraffic(remaining)
+			}
+			step++
+		}
+	}
+
+	if step >= len(steps) {
+		rlog.Info("traffic shift completed, promoting alias", "version", targetVersion)
+		desired.ko.Status.Deployment = &svcapitypes.AliasDeploymentStatus{
+			CurrentStep:     aws.Int64(int64(len(steps))),
+			CurrentWeight:   aws.Float64(1),
+			LastStepTime:    &now,
+			PreviousVersion: aws.String(previousVersion),
+			TargetVersion:   aws.String(targetVersion),
+		}
+		return nil, nil
+	}
+
+	weight := *steps[step].Weight
+	rlog.Info("shifting alias traffic", "from", previousVersion, "to", targetVersion, "step", step, "weight", weight)
+	input := &svcsdk.UpdateAliasInput{
+		FunctionName:    desired.ko.Spec.FunctionName,
+		Name:            desired.ko.Spec.Name,
+		FunctionVersion: aws.String(previousVersion),
+		RevisionId:      latest.ko.Status.RevisionID,
+		RoutingConfig: &svcsdktypes.AliasRoutingConfiguration{
+			AdditionalVersionWeights: map[string]float64{
+				targetVersion: weight,
+			},
+		},
+	}
+	var resp *svcsdk.UpdateAliasOutput
+	resp, err = rm.sdkapi.UpdateAlias(ctx, input)
+	rm.metrics.RecordAPICall("UPDATE", "UpdateAlias", err)
+	if err != nil {
+		return nil, err
+	}
+
+	ko := updatedStatusResource.ko
+	ko.Status.RevisionID = resp.RevisionId
+	ko.Status.Deployment = &svcapitypes.AliasDeploymentStatus{
+		CurrentStep:     aws.Int64(int64(step)),
+		CurrentWeight:   aws.Float64(weight),
+		LastStepTime:    &now,
+		PreviousVersion: aws.String(previousVersion),
+		TargetVersion:   aws.String(targetVersion),
+	}
+	return updatedStatusResource, requeueWaitWhileShiftingTraffic(bakeInterval(steps[step]))
+}
+
+// rollbackTraffic routes all of the alias traffic back to the previous
+// version, records the target version as failed and raises a RolledBack
+// condition naming the alarms that fired.
+func (rm *resourceManager) rollbackTraffic(
+	ctx context.Context,
+	r *resource,
+	latest *resource,
+	previousVersion string,
+	targetVersion string,
+	alarms []string,
+) (rolledBack *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.rollbackTraffic")
+	defer func() { exit(err) }()
+
+	rlog.Info("alarms fired during traffic shift, rolling back alias", "version", previousVersion, "alarms", alarms)
+	input := &svcsdk.UpdateAliasInput{
+		FunctionName:    r.ko.Spec.FunctionName,
+		Name:            r.ko.Spec.Name,
+		FunctionVersion: aws.String(previousVersion),
+		RevisionId:      latest.ko.Status.RevisionID,
+		RoutingConfig: &svcsdktypes.AliasRoutingConfiguration{
+			AdditionalVersionWeights: map[string]float64{},
+		},
+	}
+	var resp *svcsdk.UpdateAliasOutput
+	resp, err = rm.sdkapi.UpdateAlias(ctx, input)
+	rm.metrics.RecordAPICall("UPDATE", "UpdateAlias", err)
+	if err != nil {
+		return nil, err
+	}
+
+	now := metav1.Now()
+	r.ko.Status.RevisionID = resp.RevisionId
+	r.ko.Status.Deployment = &svcapitypes.AliasDeploymentStatus{
+		CurrentStep:     aws.Int64(0),
+		CurrentWeight:   aws.Float64(0),
+		LastStepTime:    &now,
+		PreviousVersion: aws.String(previousVersion),
+		TargetVersion:   aws.String(targetVersion),
+	}
+	r.ko.Status.Rollback = &svcapitypes.AliasRollbackStatus{
+		Alarms:           aws.StringSlice(alarms),
+		FailedGeneration: aws.Int64(r.ko.Generation),
+		FailedVersion:    aws.String(targetVersion),
+		PreviousVersion:  aws.String(previousVersion),
+		RolledBackAt:     &now,
+	}
+	setRolledBackCondition(r.ko)
+	return r, ackerr.NewTerminalError(errors.New(rolledBackMessage(r.ko.Status.Rollback)))
+}
+
+// alarmsInAlarmState returns the names of the supplied CloudWatch alarms that
+// are currently in the ALARM state.
+func (rm *resourceManager) alarmsInAlarmState(
+	ctx context.Context,
+	alarmNames []*string,
+) (alarming []string, err error) {
+	if len(alarmNames) == 0 {
+		return nil, nil
+	}
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.alarmsInAlarmState")
+	defer func() { exit(err) }()
+
+	input := &cloudwatch.DescribeAlarmsInput{
+		AlarmNames: aws.ToStringSlice(alarmNames),
+		AlarmTypes: []cloudwatchtypes.AlarmType{
+			cloudwatchtypes.AlarmTypeMetricAlarm,
+			cloudwatchtypes.AlarmTypeCompositeAlarm,
+		},
+		StateValue: cloudwatchtypes.StateValueAlarm,
+	}
+	paginator := cloudwatch.NewDescribeAlarmsPaginator(rm.cloudwatchapi, input)
+	for paginator.HasMorePages() {
+		var resp *cloudwatch.DescribeAlarmsOutput
+		resp, err = paginator.NextPage(ctx)
+		rm.metrics.RecordAPICall("READ_MANY", "DescribeAlarms", err)
+		if err != nil {
+			return nil, err
+		}
+		for _, alarm := range resp.MetricAlarms {
+			alarming = append(alarming, aws.ToString(alarm.AlarmName))
+		}
+		for _, alarm := range resp.CompositeAlarms {
+			alarming = append(alarming, aws.ToString(alarm.AlarmName))
+		}
+	}
+	return alarming, nil
+}
+
+// setRolledBackCondition sets the RolledBack condition of the alias from its
+// rollback status. The condition is True while the current generation still
+// targets the version whose traffic shift was rolled back, and False once a
+// newer generation superseded it.
+func setRolledBackCondition(ko *svcapitypes.Alias) {
+	rollback := ko.Status.Rollback
+	if rollback == nil || rollback.FailedGeneration == nil {
+		return
+	}
+	if *rollback.FailedGeneration == ko.Generation {
+		setCondition(ko, ConditionTypeRolledBack, corev1.ConditionTrue, rolledBackMessage(rollback))
+		return
+	}
+	setCondition(ko, ConditionTypeRolledBack, corev1.ConditionFalse, "the rolled back version was superseded by a new generation")
+}
+
+// rolledBackMessage describes the rolled back traffic shift for the
+// RolledBack condition.
+func rolledBackMessage(rollback *svcapitypes.AliasRollbackStatus) string {
+	return fmt.Sprintf(
+		"traffic shift to version %s was rolled back to version %s, alarms in ALARM state: %s",
+		aws.ToString(rollback.FailedVersion),
+		aws.ToString(rollback.PreviousVersion),
+		strings.Join(aws.ToStringSlice(rollback.Alarms), ", "),
+	)
+}
+
+// setCondition sets a condition of the supplied type on the supplied Alias.
+func setCondition(
+	ko *svcapitypes.Alias,
+	conditionType ackv1alpha1.ConditionType,
+	conditionStatus corev1.ConditionStatus,
+	message string,
+) {
+	now := metav1.Now()
+	for _, cond := range ko.Status.Conditions {
+		if cond.Type == conditionType {
+			if cond.Status != conditionStatus {
+				cond.LastTransitionTime = &now
+			}
+			cond.Status = conditionStatus
+			cond.Message = &message
+			return
+		}
+	}
+	ko.Status.Conditions = append(ko.Status.Conditions, &ackv1alpha1.Condition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		LastTransitionTime: &now,
+		Message:            &message,
+	})
+}
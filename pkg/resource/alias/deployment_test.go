@@ -0,0 +1,362 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package alias
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cloudwatchtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/lambda"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+)
+
+func Test_deploymentSteps(t *testing.T) {
+	step := func(weight float64, interval int64) *svcapitypes.AliasDeploymentStep {
+		return &svcapitypes.AliasDeploymentStep{
+			Weight:                aws.Float64(weight),
+			BakeIntervalInSeconds: aws.Int64(interval),
+		}
+	}
+	tests := []struct {
+		name        string
+		strategy    *svcapitypes.AliasDeploymentStrategy
+		wantWeights []float64
+		wantErr     bool
+	}{
+		{
+			name: "canary steps are used as is",
+			strategy: &svcapitypes.AliasDeploymentStrategy{
+				Type:  aws.String("Canary"),
+				Steps: []*svcapitypes.AliasDeploymentStep{step(0.1, 300), step(0.5, 300)},
+			},
+			wantWeights: []float64{0.1, 0.5},
+		},
+		{
+			name: "linear step is expanded",
+			strategy: &svcapitypes.AliasDeploymentStrategy{
+				Type:  aws.String("Linear"),
+				Steps: []*svcapitypes.AliasDeploymentStep{step(0.1, 60)},
+			},
+			wantWeights: []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9},
+		},
+		{
+			name: "linear step not dividing evenly",
+			strategy: &svcapitypes.AliasDeploymentStrategy{
+				Type:  aws.String("Linear"),
+				Steps: []*svcapitypes.AliasDeploymentStep{step(0.3, 60)},
+			},
+			wantWeights: []float64{0.3, 0.6, 0.9},
+		},
+		{
+			name: "linear with several steps",
+			strategy: &svcapitypes.AliasDeploymentStrategy{
+				Type:  aws.String("Linear"),
+				Steps: []*svcapitypes.AliasDeploymentStep{step(0.1, 60), step(0.2, 60)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "weight out of range",
+			strategy: &svcapitypes.AliasDeploymentStrategy{
+				Type:  aws.String("Canary"),
+				Steps: []*svcapitypes.AliasDeploymentStep{step(1, 60)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown type",
+			strategy: &svcapitypes.AliasDeploymentStrategy{
+				Type:  aws.String("AllAtOnce"),
+				Steps: []*svcapitypes.AliasDeploymentStep{step(0.5, 60)},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := deploymentSteps(tt.strategy)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("deploymentSteps() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			gotWeights := []float64{}
+			for _, s := range got {
+				gotWeights = append(gotWeights, *s.Weight)
+			}
+			if !reflect.DeepEqual(gotWeights, tt.wantWeights) {
+				t.Errorf("deploymentSteps() weights = %v, want %v", gotWeights, tt.wantWeights)
+			}
+		})
+	}
+}
+
+func Test_setRolledBackCondition(t *testing.T) {
+	ko := &svcapitypes.Alias{
+		Status: svcapitypes.AliasStatus{
+			Rollback: &svcapitypes.AliasRollbackStatus{
+				Alarms:           aws.StringSlice([]string{"errors"}),
+				FailedGeneration: aws.Int64(2),
+				FailedVersion:    aws.String("3"),
+				PreviousVersion:  aws.String("2"),
+			},
+		},
+	}
+	ko.Generation = 2
+	// The condition is derived again on every read, after the conditions
+	// were reset, and the failed version is kept when a new traffic shift
+	// overwrites the deployment status.
+	for i := 0; i < 2; i++ {
+		ko.Status.Conditions = nil
+		ko.Status.Deployment = &svcapitypes.AliasDeploymentStatus{TargetVersion: aws.String("4")}
+		setRolledBackCondition(ko)
+		if len(ko.Status.Conditions) != 1 || ko.Status.Conditions[0].Status != corev1.ConditionTrue {
+			t.Fatalf("read %d: expected a True RolledBack condition, got %v", i, ko.Status.Conditions)
+		}
+	}
+
+	ko.Generation = 3
+	setRolledBackCondition(ko)
+	if len(ko.Status.Conditions) != 1 || ko.Status.Conditions[0].Status != corev1.ConditionFalse {
+		t.Fatalf("expected a False RolledBack condition once superseded, got %v", ko.Status.Conditions)
+	}
+}
+
+// fakeAlarms returns the configured alarms as in the ALARM state.
+type fakeAlarms struct {
+	alarming []string
+}
+
+func (f *fakeAlarms) DescribeAlarms(
+	ctx context.Context,
+	input *cloudwatch.DescribeAlarmsInput,
+	optFns ...func(*cloudwatch.Options),
+) (*cloudwatch.DescribeAlarmsOutput, error) {
+	resp := &cloudwatch.DescribeAlarmsOutput{}
+	for _, name := range f.alarming {
+		resp.MetricAlarms = append(resp.MetricAlarms, cloudwatchtypes.MetricAlarm{AlarmName: aws.String(name)})
+	}
+	return resp, nil
+}
+
+// updateAliasRequest is the body of an UpdateAlias request.
+type updateAliasRequest struct {
+	FunctionVersion string
+	RoutingConfig   struct {
+		AdditionalVersionWeights map[string]float64
+	}
+}
+
+// fakeLambda records the UpdateAlias requests and accepts them.
+type fakeLambda struct {
+	requests []updateAliasRequest
+}
+
+func (f *fakeLambda) Do(req *http.Request) (*http.Response, error) {
+	var body updateAliasRequest
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	f.requests = append(f.requests, body)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Content-Type":     []string{"application/json"},
+			"X-Amzn-Requestid": []string{"test"},
+		},
+		Body:    io.NopCloser(strings.NewReader(`{"RevisionId":"revision-2"}`)),
+		Request: req,
+	}, nil
+}
+
+func newTestResourceManager(lambda *fakeLambda, alarms *fakeAlarms) *resourceManager {
+	return &resourceManager{
+		metrics: ackmetrics.NewMetrics("lambda"),
+		sdkapi: svcsdk.New(svcsdk.Options{
+			Region:           "us-west-2",
+			Credentials:      aws.AnonymousCredentials{},
+			HTTPClient:       lambda,
+			RetryMaxAttempts: 1,
+		}),
+		cloudwatchapi: alarms,
+	}
+}
+
+// newShift returns the desired and latest aliases of a traffic shift from
+// version 1 to version 2 in two canary steps.
+func newShift() (*resource, *resource) {
+	desired := &resource{ko: &svcapitypes.Alias{
+		Spec: svcapitypes.AliasSpec{
+			FunctionName:    aws.String("my-function"),
+			Name:            aws.String("live"),
+			FunctionVersion: aws.String("2"),
+			DeploymentStrategy: &svcapitypes.AliasDeploymentStrategy{
+				Type:   aws.String(string(svcapitypes.AliasDeploymentStrategyType_Canary)),
+				Alarms: aws.StringSlice([]string{"errors"}),
+				Steps: []*svcapitypes.AliasDeploymentStep{
+					{Weight: aws.Float64(0.1), BakeIntervalInSeconds: aws.Int64(60)},
+					{Weight: aws.Float64(0.5), BakeIntervalInSeconds: aws.Int64(60)},
+				},
+			},
+		},
+	}}
+	desired.ko.Generation = 2
+	latest := &resource{ko: desired.ko.DeepCopy()}
+	latest.ko.Spec.FunctionVersion = aws.String("1")
+	latest.ko.Status.RevisionID = aws.String("revision-1")
+	return desired, latest
+}
+
+func requeueAfter(t *testing.T, err error) time.Duration {
+	t.Helper()
+	var requeueErr *ackrequeue.RequeueNeededAfter
+	if !errors.As(err, &requeueErr) {
+		t.Fatalf("expected a requeue error, got %v", err)
+	}
+	return requeueErr.Duration()
+}
+
+func Test_shiftTraffic_firstStep(t *testing.T) {
+	lambda := &fakeLambda{}
+	rm := newTestResourceManager(lambda, &fakeAlarms{})
+	desired, latest := newShift()
+
+	shifted, err := rm.shiftTraffic(context.Background(), desired, latest)
+	if got := requeueAfter(t, err); got != time.Minute {
+		t.Errorf("requeue after %v, want 1m", got)
+	}
+	if len(lambda.requests) != 1 {
+		t.Fatalf("expected 1 UpdateAlias call, got %d", len(lambda.requests))
+	}
+	req := lambda.requests[0]
+	if req.FunctionVersion != "1" || !reflect.DeepEqual(req.RoutingConfig.AdditionalVersionWeights, map[string]float64{"2": 0.1}) {
+		t.Errorf("UpdateAlias request = %+v, want version 1 with 10%% of traffic on version 2", req)
+	}
+	deployment := shifted.ko.Status.Deployment
+	if aws.ToInt64(deployment.CurrentStep) != 0 || aws.ToFloat64(deployment.CurrentWeight) != 0.1 {
+		t.Errorf("deployment = step %d, weight %v, want step 0, weight 0.1", aws.ToInt64(deployment.CurrentStep), aws.ToFloat64(deployment.CurrentWeight))
+	}
+}
+
+func Test_shiftTraffic_baking(t *testing.T) {
+	lambda := &fakeLambda{}
+	rm := newTestResourceManager(lambda, &fakeAlarms{})
+	desired, latest := newShift()
+	stepTime := metav1.NewTime(time.Now().Add(-20 * time.Second))
+	desired.ko.Status.Deployment = &svcapitypes.AliasDeploymentStatus{
+		CurrentStep:     aws.Int64(0),
+		CurrentWeight:   aws.Float64(0.1),
+		LastStepTime:    &stepTime,
+		PreviousVersion: aws.String("1"),
+		TargetVersion:   aws.String("2"),
+	}
+
+	_, err := rm.shiftTraffic(context.Background(), desired, latest)
+	if got := requeueAfter(t, err); got <= 0 || got > 40*time.Second {
+		t.Errorf("requeue after %v, want the remaining bake interval", got)
+	}
+	if len(lambda.requests) != 0 {
+		t.Errorf("expected no UpdateAlias call while baking, got %d", len(lambda.requests))
+	}
+}
+
+func Test_shiftTraffic_completed(t *testing.T) {
+	lambda := &fakeLambda{}
+	rm := newTestResourceManager(lambda, &fakeAlarms{})
+	desired, latest := newShift()
+	stepTime := metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	desired.ko.Status.Deployment = &svcapitypes.AliasDeploymentStatus{
+		CurrentStep:     aws.Int64(1),
+		CurrentWeight:   aws.Float64(0.5),
+		LastStepTime:    &stepTime,
+		PreviousVersion: aws.String("1"),
+		TargetVersion:   aws.String("2"),
+	}
+
+	shifted, err := rm.shiftTraffic(context.Background(), desired, latest)
+	if shifted != nil || err != nil {
+		t.Fatalf("shiftTraffic() = %v, %v, want nil, nil to promote the alias", shifted, err)
+	}
+	if len(lambda.requests) != 0 {
+		t.Errorf("expected the promotion to be left to the caller, got %d UpdateAlias calls", len(lambda.requests))
+	}
+	if got := aws.ToFloat64(desired.ko.Status.Deployment.CurrentWeight); got != 1 {
+		t.Errorf("deployment weight = %v, want 1", got)
+	}
+}
+
+func Test_shiftTraffic_alarmRollsBack(t *testing.T) {
+	lambda := &fakeLambda{}
+	rm := newTestResourceManager(lambda, &fakeAlarms{alarming: []string{"errors"}})
+	desired, latest := newShift()
+
+	rolledBack, err := rm.shiftTraffic(context.Background(), desired, latest)
+	var terminalErr *ackerr.TerminalError
+	if !errors.As(err, &terminalErr) {
+		t.Fatalf("expected a terminal error, got %v", err)
+	}
+	if len(lambda.requests) != 1 {
+		t.Fatalf("expected 1 UpdateAlias call, got %d", len(lambda.requests))
+	}
+	if req := lambda.requests[0]; req.FunctionVersion != "1" || len(req.RoutingConfig.AdditionalVersionWeights) != 0 {
+		t.Errorf("UpdateAlias request = %+v, want all of the traffic on version 1", req)
+	}
+	rollback := rolledBack.ko.Status.Rollback
+	if rollback == nil || aws.ToString(rollback.FailedVersion) != "2" || aws.ToInt64(rollback.FailedGeneration) != 2 {
+		t.Fatalf("rollback status = %+v, want version 2 failed at generation 2", rollback)
+	}
+	if len(rolledBack.ko.Status.Conditions) != 1 || rolledBack.ko.Status.Conditions[0].Status != corev1.ConditionTrue {
+		t.Errorf("expected a True RolledBack condition, got %v", rolledBack.ko.Status.Conditions)
+	}
+}
+
+func Test_shiftTraffic_failedVersion(t *testing.T) {
+	lambda := &fakeLambda{}
+	rm := newTestResourceManager(lambda, &fakeAlarms{})
+	desired, latest := newShift()
+	latest.ko.Status.Rollback = &svcapitypes.AliasRollbackStatus{
+		FailedGeneration: aws.Int64(1),
+		FailedVersion:    aws.String("2"),
+		PreviousVersion:  aws.String("1"),
+	}
+
+	refused, err := rm.shiftTraffic(context.Background(), desired, latest)
+	var terminalErr *ackerr.TerminalError
+	if !errors.As(err, &terminalErr) {
+		t.Fatalf("expected a terminal error, got %v", err)
+	}
+	if len(lambda.requests) != 0 {
+		t.Errorf("expected no UpdateAlias call, got %d", len(lambda.requests))
+	}
+	if got := aws.ToInt64(refused.ko.Status.Rollback.FailedGeneration); got != 2 {
+		t.Errorf("FailedGeneration = %d, want the current generation 2", got)
+	}
+}
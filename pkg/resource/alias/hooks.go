@@ -17,17 +17,20 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
 	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
 	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/applicationautoscaling"
 	svcsdk "github.com/aws/aws-sdk-go-v2/service/lambda"
 	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
 	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
 	"github.com/micahhausler/aws-iam-policy/policy"
 
 	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/lambda-controller/pkg/resource/autoscaling"
 )
 
 // syncEventInvokeConfig calls `PutFunctionEventInvokeConfig` to update the fields
@@ -93,19 +96,37 @@ func (rm *resourceManager) syncEventInvokeConfig(
 }
 
 // updateProvisionedConcurrency calls `PutProvisionedConcurrencyConfig` to update the fields
-// or `DeleteProvisionedConcurrencyConfig` if users removes the fields
+// or `DeleteProvisionedConcurrencyConfig` if users removes the fields. It also
+// registers the provisioned concurrency with Application Auto Scaling when
+// autoscaling is configured.
 func (rm *resourceManager) updateProvisionedConcurrency(
 	ctx context.Context,
 	desired *resource,
+	latest *resource,
 ) error {
 	var err error
 	rlog := ackrtlog.FromContext(ctx)
 	exit := rlog.Trace("rm.updateProvisionedConcurrency")
 	defer exit(err)
 
+	resourceID := autoscaling.ResourceID(*desired.ko.Spec.FunctionName, *desired.ko.Spec.Name)
+	var latestConfig *svcapitypes.PutProvisionedConcurrencyConfigInput
+	var latestAutoscaling *svcapitypes.ProvisionedConcurrencyAutoscaling
+	if latest != nil && latest.ko.Spec.ProvisionedConcurrencyConfig != nil {
+		latestConfig = latest.ko.Spec.ProvisionedConcurrencyConfig
+		latestAutoscaling = latestConfig.Autoscaling
+	}
+
 	// Check if the user deleted the 'ProvisionedConcurrency' configuration
 	// If yes, delete ProvisionedConcurrencyConfig
-	if desired.ko.Spec.ProvisionedConcurrencyConfig == nil || desired.ko.Spec.ProvisionedConcurrencyConfig.ProvisionedConcurrentExecutions == nil {
+	dconfig := desired.ko.Spec.ProvisionedConcurrencyConfig
+	if dconfig == nil || (dconfig.ProvisionedConcurrentExecutions == nil && dconfig.Autoscaling == nil) {
+		if latestAutoscaling != nil {
+			err = autoscaling.DeregisterProvisionedConcurrencyAutoscaling(ctx, rm.autoscalingClient(), rm.metrics, resourceID)
+			if err != nil {
+				return err
+			}
+		}
 		input_delete := &svcsdk.DeleteProvisionedConcurrencyConfigInput{
 			FunctionName: aws.String(*desired.ko.Spec.FunctionName),
 			Qualifier:    aws.String(*desired.ko.Spec.Name),
@@ -118,19 +139,49 @@ func (rm *resourceManager) updateProvisionedConcurrency(
 		return nil
 	}
 
-	dspec := desired.ko.Spec
-	input := &svcsdk.PutProvisionedConcurrencyConfigInput{
-		FunctionName:                    aws.String(*dspec.FunctionName),
-		Qualifier:                       aws.String(*dspec.Name),
-		ProvisionedConcurrentExecutions: int32OrNil(dspec.ProvisionedConcurrencyConfig.ProvisionedConcurrentExecutions),
+	// With autoscaling the scaler owns the allocated provisioned concurrency,
+	// so only put the initial value when none is allocated yet.
+	executions := dconfig.ProvisionedConcurrentExecutions
+	if executions == nil {
+		executions = dconfig.Autoscaling.MinCapacity
 	}
+	if dconfig.Autoscaling == nil || latestConfig == nil {
+		input := &svcsdk.PutProvisionedConcurrencyConfigInput{
+			FunctionName:                    aws.String(*desired.ko.Spec.FunctionName),
+			Qualifier:                       aws.String(*desired.ko.Spec.Name),
+			ProvisionedConcurrentExecutions: int32OrNil(executions),
+		}
 
-	_, err = rm.sdkapi.PutProvisionedConcurrencyConfig(ctx, input)
-	rm.metrics.RecordAPICall("UPDATE", "UpdateProvisionedConcurrency", err)
-	if err != nil {
-		return err
+		_, err = rm.sdkapi.PutProvisionedConcurrencyConfig(ctx, input)
+		rm.metrics.RecordAPICall("UPDATE", "UpdateProvisionedConcurrency", err)
+		if err != nil {
+			return err
+		}
 	}
-	return nil
+
+	return autoscaling.SyncProvisionedConcurrencyAutoscaling(
+		ctx, rm.autoscalingClient(), rm.metrics, resourceID,
+		dconfig.Autoscaling, latestAutoscaling,
+	)
+}
+
+// deregisterProvisionedConcurrencyAutoscaling removes the Application Auto
+// Scaling target of the alias provisioned concurrency, if any.
+func (rm *resourceManager) deregisterProvisionedConcurrencyAutoscaling(
+	ctx context.Context,
+	r *resource,
+) error {
+	if r.ko.Spec.ProvisionedConcurrencyConfig == nil || r.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling == nil {
+		return nil
+	}
+	return autoscaling.DeregisterProvisionedConcurrencyAutoscaling(
+		ctx, rm.autoscalingClient(), rm.metrics,
+		autoscaling.ResourceID(*r.ko.Spec.FunctionName, *r.ko.Spec.Name),
+	)
+}
+
+func (rm *resourceManager) autoscalingClient() *applicationautoscaling.Client {
+	return applicationautoscaling.NewFromConfig(rm.clientcfg)
 }
 
 // setProvisionedConcurrencyConfig sets the Provisioned Concurrency
@@ -156,6 +207,7 @@ func (rm *resourceManager) setProvisionedConcurrencyConfig(
 	if err != nil {
 		if awserr, ok := ackerr.AWSError(err); ok && (awserr.ErrorCode() == "ProvisionedConcurrencyConfigNotFoundException" || awserr.ErrorCode() == "ResourceNotFoundException") {
 			ko.Spec.ProvisionedConcurrencyConfig = nil
+			ko.Status.ProvisionedConcurrency = nil
 		} else {
 			return err
 		}
@@ -163,6 +215,31 @@ func (rm *resourceManager) setProvisionedConcurrencyConfig(
 		// creating ProvisionedConcurrency object to store the values returned from `Get` call
 		cloudProvisionedConcurrency := &svcapitypes.PutProvisionedConcurrencyConfigInput{}
 		cloudProvisionedConcurrency.ProvisionedConcurrentExecutions = int64OrNil(getProvisionedConcurrencyConfigOutput.RequestedProvisionedConcurrentExecutions)
+		var desiredAutoscaling *svcapitypes.ProvisionedConcurrencyAutoscaling
+		if ko.Spec.ProvisionedConcurrencyConfig != nil {
+			desiredAutoscaling = ko.Spec.ProvisionedConcurrencyConfig.Autoscaling
+		}
+		if autoscaling.InUse(desiredAutoscaling, ko.Status.ProvisionedConcurrency) {
+			cloudProvisionedConcurrency.Autoscaling, err = autoscaling.GetProvisionedConcurrencyAutoscaling(
+				ctx, rm.autoscalingClient(), rm.metrics,
+				autoscaling.ResourceID(*ko.Spec.FunctionName, *ko.Spec.Name),
+			)
+			if err != nil {
+				return err
+			}
+		}
+		ko.Status.ProvisionedConcurrency = autoscaling.ProvisionedConcurrencyStatus(cloudProvisionedConcurrency.Autoscaling, time.Now())
+		ko.Status.ProvisionedConcurrency.AllocatedProvisionedConcurrentExecutions = int64OrNil(getProvisionedConcurrencyConfigOutput.AllocatedProvisionedConcurrentExecutions)
+		ko.Status.ProvisionedConcurrency.Status = aws.String(string(getProvisionedConcurrencyConfigOutput.Status))
+		if cloudProvisionedConcurrency.Autoscaling != nil {
+			// The allocated value is owned by the scaler, keep the requested
+			// initial value so that scaling doesn't show up as a difference.
+			cloudProvisionedConcurrency.ProvisionedConcurrentExecutions = nil
+			if ko.Spec.ProvisionedConcurrencyConfig != nil {
+				cloudProvisionedConcurrency.ProvisionedConcurrentExecutions = ko.Spec.ProvisionedConcurrencyConfig.ProvisionedConcurrentExecutions
+				autoscaling.NormalizeObserved(cloudProvisionedConcurrency.Autoscaling, ko.Spec.ProvisionedConcurrencyConfig.Autoscaling)
+			}
+		}
 		ko.Spec.ProvisionedConcurrencyConfig = cloudProvisionedConcurrency
 	}
 
@@ -279,6 +356,9 @@ func (rm *resourceManager) setResourceAdditionalFields(
 		return err
 	}
 
+	// To set the RolledBack condition of the last rolled back traffic shift
+	setRolledBackCondition(ko)
+
 	return nil
 }
 
@@ -525,6 +605,55 @@ func (rm *resourceManager) addPermission(
 	return err
 }
 
+// addVersionWeightRefs adds the weights of the resolved
+// AdditionalVersionWeightRefs to the routing configuration sent to Lambda.
+func addVersionWeightRefs(
+	routing *svcsdktypes.AliasRoutingConfiguration,
+	spec *svcapitypes.AliasRoutingConfiguration,
+) *svcsdktypes.AliasRoutingConfiguration {
+	if len(spec.AdditionalVersionWeightRefs) == 0 {
+		return routing
+	}
+	if routing == nil {
+		routing = &svcsdktypes.AliasRoutingConfiguration{}
+	}
+	if routing.AdditionalVersionWeights == nil {
+		routing.AdditionalVersionWeights = map[string]float64{}
+	}
+	for _, ref := range spec.AdditionalVersionWeightRefs {
+		if ref.FunctionVersion == nil || ref.Weight == nil {
+			continue
+		}
+		routing.AdditionalVersionWeights[*ref.FunctionVersion] = *ref.Weight
+	}
+	return routing
+}
+
+// setAdditionalVersionWeightRefs splits the weights returned by Lambda back
+// into AdditionalVersionWeights and the AdditionalVersionWeightRefs of the
+// supplied routing configuration, so that the observed state compares with
+// the spec. The weight of a referenced version missing from the alias is left
+// nil.
+func setAdditionalVersionWeightRefs(
+	ko *svcapitypes.Alias,
+	spec *svcapitypes.AliasRoutingConfiguration,
+) {
+	if spec == nil || len(spec.AdditionalVersionWeightRefs) == 0 || ko.Spec.RoutingConfig == nil {
+		return
+	}
+	observed := ko.Spec.RoutingConfig.AdditionalVersionWeights
+	refs := make([]*svcapitypes.AliasVersionWeight, 0, len(spec.AdditionalVersionWeightRefs))
+	for _, ref := range spec.AdditionalVersionWeightRefs {
+		ref = ref.DeepCopy()
+		if ref.FunctionVersion != nil {
+			ref.Weight = observed[*ref.FunctionVersion]
+			delete(observed, *ref.FunctionVersion)
+		}
+		refs = append(refs, ref)
+	}
+	ko.Spec.RoutingConfig.AdditionalVersionWeightRefs = refs
+}
+
 func customPreCompare(
 	delta *ackcompare.Delta,
 	a *resource,
@@ -33,6 +33,7 @@ import (
 	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
 	ackutil "github.com/aws-controllers-k8s/runtime/pkg/util"
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
 	svcsdk "github.com/aws/aws-sdk-go-v2/service/lambda"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -80,6 +81,9 @@ type resourceManager struct {
 	// sdk is a pointer to the AWS service API client exposed by the
 	// aws-sdk-go-v2/services/{alias} package.
 	sdkapi *svcsdk.Client
+	// cloudwatchapi is the CloudWatch API client used to check the alarms
+	// watched by the deployment strategy.
+	cloudwatchapi cloudwatch.DescribeAlarmsAPIClient
 }
 
 // concreteResource returns a pointer to a resource from the supplied
@@ -341,15 +345,16 @@ func newResourceManager(
 	region ackv1alpha1.AWSRegion,
 ) (*resourceManager, error) {
 	return &resourceManager{
-		cfg:          cfg,
-		clientcfg:    clientcfg,
-		log:          log,
-		metrics:      metrics,
-		rr:           rr,
-		awsAccountID: id,
-		awsRegion:    region,
-		awsPartition: ackv1alpha1.AWSPartition(cfg.Partition),
-		sdkapi:       svcsdk.NewFromConfig(clientcfg),
+		cfg:           cfg,
+		clientcfg:     clientcfg,
+		log:           log,
+		metrics:       metrics,
+		rr:            rr,
+		awsAccountID:  id,
+		awsRegion:     region,
+		awsPartition:  ackv1alpha1.AWSPartition(cfg.Partition),
+		sdkapi:        svcsdk.NewFromConfig(clientcfg),
+		cloudwatchapi: cloudwatch.NewFromConfig(clientcfg),
 	}, nil
 }
 
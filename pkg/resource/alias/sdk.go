@@ -128,6 +128,7 @@ func (rm *resourceManager) sdkFind(
 	}
 
 	rm.setStatusDefaults(ko)
+	setAdditionalVersionWeightRefs(ko, r.ko.Spec.RoutingConfig)
 	if err := rm.setResourceAdditionalFields(ctx, ko); err != nil {
 		return nil, err
 	}
@@ -177,6 +178,9 @@ func (rm *resourceManager) sdkCreate(
 	if err != nil {
 		return nil, err
 	}
+	if desired.ko.Spec.RoutingConfig != nil {
+		input.RoutingConfig = addVersionWeightRefs(input.RoutingConfig, desired.ko.Spec.RoutingConfig)
+	}
 
 	var resp *svcsdk.CreateAliasOutput
 	_ = resp
@@ -227,6 +231,8 @@ func (rm *resourceManager) sdkCreate(
 	}
 
 	rm.setStatusDefaults(ko)
+	setAdditionalVersionWeightRefs(ko, desired.ko.Spec.RoutingConfig)
+
 	if ko.Spec.FunctionEventInvokeConfig != nil {
 		_, err = rm.syncEventInvokeConfig(ctx, desired)
 		if err != nil {
@@ -235,7 +241,7 @@ func (rm *resourceManager) sdkCreate(
 	}
 
 	if ko.Spec.ProvisionedConcurrencyConfig != nil {
-		err = rm.updateProvisionedConcurrency(ctx, desired)
+		err = rm.updateProvisionedConcurrency(ctx, desired, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -303,7 +309,7 @@ func (rm *resourceManager) sdkUpdate(
 		}
 	}
 	if delta.DifferentAt("Spec.ProvisionedConcurrencyConfig") {
-		err = rm.updateProvisionedConcurrency(ctx, desired)
+		err = rm.updateProvisionedConcurrency(ctx, desired, latest)
 		if err != nil {
 			return nil, err
 		}
@@ -317,11 +323,31 @@ func (rm *resourceManager) sdkUpdate(
 	if !delta.DifferentExcept("Spec.ProvisionedConcurrencyConfig", "Spec.FunctionEventInvokeConfig", "Spec.Permissions") {
 		return desired, nil
 	}
+	if desired.ko.Spec.DeploymentStrategy != nil && delta.DifferentAt("Spec.FunctionVersion") {
+		var shifted *resource
+		shifted, err = rm.shiftTraffic(ctx, desired, latest)
+		if shifted != nil || err != nil {
+			return shifted, err
+		}
+	}
 	input, err := rm.newUpdateRequestPayload(ctx, desired, delta)
 	if err != nil {
 		return nil, err
 	}
 
+	if desired.ko.Spec.RoutingConfig != nil {
+		input.RoutingConfig = addVersionWeightRefs(input.RoutingConfig, desired.ko.Spec.RoutingConfig)
+	}
+	// UpdateAlias leaves the existing routing configuration in place when
+	// RoutingConfig is omitted, so send an empty one to clear the weights.
+	if input.RoutingConfig == nil && latest.ko.Spec.RoutingConfig != nil &&
+		(len(latest.ko.Spec.RoutingConfig.AdditionalVersionWeights) > 0 ||
+			len(latest.ko.Spec.RoutingConfig.AdditionalVersionWeightRefs) > 0) {
+		input.RoutingConfig = &svcsdktypes.AliasRoutingConfiguration{
+			AdditionalVersionWeights: map[string]float64{},
+		}
+	}
+
 	var resp *svcsdk.UpdateAliasOutput
 	_ = resp
 	resp, err = rm.sdkapi.UpdateAlias(ctx, input)
@@ -371,6 +397,7 @@ func (rm *resourceManager) sdkUpdate(
 	}
 
 	rm.setStatusDefaults(ko)
+	setAdditionalVersionWeightRefs(ko, desired.ko.Spec.RoutingConfig)
 	return &resource{ko}, nil
 }
 
@@ -419,6 +446,10 @@ func (rm *resourceManager) sdkDelete(
 	defer func() {
 		exit(err)
 	}()
+	if err = rm.deregisterProvisionedConcurrencyAutoscaling(ctx, r); err != nil {
+		return nil, err
+	}
+
 	input, err := rm.newDeleteRequestPayload(r)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,215 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package autoscaling
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+)
+
+// lookback bounds how far in the past the last firing time of a cron
+// schedule is searched for.
+const lookback = 366 * 24 * time.Hour
+
+var (
+	monthNames = map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}
+	weekdayNames = map[string]int{
+		"SUN": 1, "MON": 2, "TUE": 3, "WED": 4, "THU": 5, "FRI": 6, "SAT": 7,
+	}
+)
+
+// ActiveScheduledAction returns the scheduled action whose schedule fired
+// last before now, along with the time it fired. It returns nil when none of
+// the actions has fired yet or when their schedules can't be evaluated.
+//
+// Only at() and cron() expressions are evaluated. The L, W and # cron
+// extensions, as well as rate() expressions, are not supported.
+func ActiveScheduledAction(
+	actions []*svcapitypes.ProvisionedConcurrencyScheduledAction,
+	now time.Time,
+) (*svcapitypes.ProvisionedConcurrencyScheduledAction, time.Time) {
+	var active *svcapitypes.ProvisionedConcurrencyScheduledAction
+	var activeSince time.Time
+	for _, action := range actions {
+		if action == nil || action.Schedule == nil {
+			continue
+		}
+		loc := time.UTC
+		if action.Timezone != nil {
+			if l, err := time.LoadLocation(*action.Timezone); err == nil {
+				loc = l
+			}
+		}
+		fired, ok := lastFired(*action.Schedule, now.In(loc))
+		if !ok {
+			continue
+		}
+		if active == nil || fired.After(activeSince) {
+			active = action
+			activeSince = fired
+		}
+	}
+	return active, activeSince
+}
+
+// lastFired returns the last time at or before now the supplied schedule
+// expression fired.
+func lastFired(schedule string, now time.Time) (time.Time, bool) {
+	schedule = strings.TrimSpace(schedule)
+	switch {
+	case strings.HasPrefix(schedule, "at(") && strings.HasSuffix(schedule, ")"):
+		at, err := time.ParseInLocation("2006-01-02T15:04:05", schedule[3:len(schedule)-1], now.Location())
+		if err != nil || at.After(now) {
+			return time.Time{}, false
+		}
+		return at, true
+	case strings.HasPrefix(schedule, "cron(") && strings.HasSuffix(schedule, ")"):
+		expr, err := parseCron(schedule[5 : len(schedule)-1])
+		if err != nil {
+			return time.Time{}, false
+		}
+		return expr.prev(now)
+	}
+	return time.Time{}, false
+}
+
+// cronExpr is a parsed Application Auto Scaling cron expression. Each field
+// holds the set of values it matches.
+type cronExpr struct {
+	minutes  map[int]bool
+	hours    map[int]bool
+	days     map[int]bool
+	months   map[int]bool
+	weekdays map[int]bool
+	years    map[int]bool
+}
+
+func parseCron(s string) (*cronExpr, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 6 {
+		return nil, fmt.Errorf("cron expression %q must have 6 fields", s)
+	}
+	var err error
+	expr := &cronExpr{}
+	if expr.minutes, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, err
+	}
+	if expr.hours, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, err
+	}
+	if expr.days, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, err
+	}
+	if expr.months, err = parseCronField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, err
+	}
+	if expr.weekdays, err = parseCronField(fields[4], 1, 7, weekdayNames); err != nil {
+		return nil, err
+	}
+	if expr.years, err = parseCronField(fields[5], 1970, 2199, nil); err != nil {
+		return nil, err
+	}
+	return expr, nil
+}
+
+// parseCronField parses a comma separated list of values, ranges and steps.
+// A nil set means the field matches any value.
+func parseCronField(field string, min, max int, names map[string]int) (map[int]bool, error) {
+	if field == "*" || field == "?" {
+		return nil, nil
+	}
+	set := map[int]bool{}
+	for _, part := range strings.Split(field, ",") {
+		rng, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rng = part[:i]
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid cron step %q", part)
+			}
+		}
+		lo, hi := min, max
+		if rng != "*" {
+			bounds := strings.SplitN(rng, "-", 2)
+			var err error
+			if lo, err = parseCronValue(bounds[0], names); err != nil {
+				return nil, err
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = parseCronValue(bounds[1], names); err != nil {
+					return nil, err
+				}
+			} else if step > 1 {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return nil, fmt.Errorf("cron value %q out of range", part)
+		}
+		for v := lo; v <= hi; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
+
+func parseCronValue(s string, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToUpper(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("unsupported cron value %q", s)
+	}
+	return v, nil
+}
+
+func matches(set map[int]bool, v int) bool {
+	return set == nil || set[v]
+}
+
+// prev returns the last minute at or before t matched by the expression.
+func (e *cronExpr) prev(t time.Time) (time.Time, bool) {
+	loc := t.Location()
+	t = t.Truncate(time.Minute)
+	limit := t.Add(-lookback)
+	for !t.Before(limit) {
+		y, m, d := t.Date()
+		if !matches(e.years, y) || !matches(e.months, int(m)) ||
+			!matches(e.days, d) || !matches(e.weekdays, int(t.Weekday())+1) {
+			// Jump to the last minute of the previous day.
+			t = time.Date(y, m, d, 0, 0, 0, 0, loc).Add(-time.Minute)
+			continue
+		}
+		if !matches(e.hours, t.Hour()) {
+			t = time.Date(y, m, d, t.Hour(), 0, 0, 0, loc).Add(-time.Minute)
+			continue
+		}
+		if !matches(e.minutes, t.Minute()) {
+			t = t.Add(-time.Minute)
+			continue
+		}
+		return t, true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,110 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package autoscaling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+)
+
+func Test_lastFired(t *testing.T) {
+	// Wednesday
+	now := time.Date(2026, 10, 21, 14, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		schedule string
+		want     time.Time
+		wantOk   bool
+	}{
+		{
+			name:     "business hours start",
+			schedule: "cron(0 8 ? * MON-FRI *)",
+			want:     time.Date(2026, 10, 21, 8, 0, 0, 0, time.UTC),
+			wantOk:   true,
+		},
+		{
+			name:     "night start fired the previous day",
+			schedule: "cron(0 20 ? * MON-FRI *)",
+			want:     time.Date(2026, 10, 20, 20, 0, 0, 0, time.UTC),
+			wantOk:   true,
+		},
+		{
+			name:     "weekend only",
+			schedule: "cron(0 9 ? * SAT,SUN *)",
+			want:     time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC),
+			wantOk:   true,
+		},
+		{
+			name:     "minute steps",
+			schedule: "cron(*/15 * * * ? *)",
+			want:     time.Date(2026, 10, 21, 14, 30, 0, 0, time.UTC),
+			wantOk:   true,
+		},
+		{
+			name:     "one time in the past",
+			schedule: "at(2026-10-01T12:00:00)",
+			want:     time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+			wantOk:   true,
+		},
+		{
+			name:     "one time in the future",
+			schedule: "at(2026-12-24T18:00:00)",
+		},
+		{
+			name:     "unsupported extension",
+			schedule: "cron(0 8 L * ? *)",
+		},
+		{
+			name:     "rate expressions are not evaluated",
+			schedule: "rate(5 minutes)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := lastFired(tt.schedule, now)
+			if ok != tt.wantOk {
+				t.Fatalf("lastFired() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if ok && !got.Equal(tt.want) {
+				t.Errorf("lastFired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ActiveScheduledAction(t *testing.T) {
+	now := time.Date(2026, 10, 21, 14, 30, 0, 0, time.UTC)
+	actions := []*svcapitypes.ProvisionedConcurrencyScheduledAction{
+		{
+			Name:     aws.String("business-hours"),
+			Schedule: aws.String("cron(0 8 ? * MON-FRI *)"),
+			Timezone: aws.String("UTC"),
+		},
+		{
+			Name:     aws.String("night"),
+			Schedule: aws.String("cron(0 20 ? * MON-FRI *)"),
+		},
+	}
+	active, since := ActiveScheduledAction(actions, now)
+	if active == nil || *active.Name != "business-hours" {
+		t.Fatalf("ActiveScheduledAction() = %v, want business-hours", active)
+	}
+	if !since.Equal(time.Date(2026, 10, 21, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("ActiveScheduledAction() since = %v", since)
+	}
+}
@@ -0,0 +1,412 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package autoscaling
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/applicationautoscaling"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/applicationautoscaling/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+)
+
+// TargetTrackingPolicyName is the name of the target tracking scaling policy
+// the controller attaches to a provisioned concurrency scalable target.
+const TargetTrackingPolicyName = "ack-provisioned-concurrency-utilization"
+
+type metricsRecorder interface {
+	RecordAPICall(opType string, opID string, err error)
+}
+
+type autoscalingClient interface {
+	DescribeScalableTargets(context.Context, *svcsdk.DescribeScalableTargetsInput, ...func(*svcsdk.Options)) (*svcsdk.DescribeScalableTargetsOutput, error)
+	DescribeScalingPolicies(context.Context, *svcsdk.DescribeScalingPoliciesInput, ...func(*svcsdk.Options)) (*svcsdk.DescribeScalingPoliciesOutput, error)
+	RegisterScalableTarget(context.Context, *svcsdk.RegisterScalableTargetInput, ...func(*svcsdk.Options)) (*svcsdk.RegisterScalableTargetOutput, error)
+	DeregisterScalableTarget(context.Context, *svcsdk.DeregisterScalableTargetInput, ...func(*svcsdk.Options)) (*svcsdk.DeregisterScalableTargetOutput, error)
+	PutScalingPolicy(context.Context, *svcsdk.PutScalingPolicyInput, ...func(*svcsdk.Options)) (*svcsdk.PutScalingPolicyOutput, error)
+	DeleteScalingPolicy(context.Context, *svcsdk.DeleteScalingPolicyInput, ...func(*svcsdk.Options)) (*svcsdk.DeleteScalingPolicyOutput, error)
+	DescribeScheduledActions(context.Context, *svcsdk.DescribeScheduledActionsInput, ...func(*svcsdk.Options)) (*svcsdk.DescribeScheduledActionsOutput, error)
+	PutScheduledAction(context.Context, *svcsdk.PutScheduledActionInput, ...func(*svcsdk.Options)) (*svcsdk.PutScheduledActionOutput, error)
+	DeleteScheduledAction(context.Context, *svcsdk.DeleteScheduledActionInput, ...func(*svcsdk.Options)) (*svcsdk.DeleteScheduledActionOutput, error)
+}
+
+// ResourceID returns the Application Auto Scaling resource identifier of the
+// provisioned concurrency of a function alias or version.
+func ResourceID(functionName string, qualifier string) string {
+	// Application Auto Scaling only accepts the function name, so strip the
+	// ARN prefix if the function was referenced by ARN.
+	if idx := strings.LastIndex(functionName, ":function:"); idx >= 0 {
+		functionName = functionName[idx+len(":function:"):]
+	}
+	return fmt.Sprintf("function:%s:%s", functionName, qualifier)
+}
+
+// InUse returns true if autoscaling is desired, or if a scalable target was
+// observed on the last read and may have to be deregistered. Otherwise
+// looking up the autoscaling configuration is skipped, so that reads of
+// resources that don't use autoscaling don't call Application Auto Scaling.
+func InUse(
+	desired *svcapitypes.ProvisionedConcurrencyAutoscaling,
+	status *svcapitypes.ProvisionedConcurrencyStatus,
+) bool {
+	if desired != nil {
+		return true
+	}
+	return status != nil && (status.CurrentMinCapacity != nil || status.CurrentMaxCapacity != nil)
+}
+
+// GetProvisionedConcurrencyAutoscaling returns the autoscaling configuration
+// registered for the supplied resource identifier, or nil if the provisioned
+// concurrency is not registered as a scalable target.
+func GetProvisionedConcurrencyAutoscaling(
+	ctx context.Context,
+	client autoscalingClient,
+	mr metricsRecorder,
+	resourceID string,
+) (*svcapitypes.ProvisionedConcurrencyAutoscaling, error) {
+	targets, err := client.DescribeScalableTargets(ctx, &svcsdk.DescribeScalableTargetsInput{
+		ServiceNamespace:  svcsdktypes.ServiceNamespaceLambda,
+		ScalableDimension: svcsdktypes.ScalableDimensionLambdaFunctionProvisionedConcurrency,
+		ResourceIds:       []string{resourceID},
+	})
+	mr.RecordAPICall("READ_MANY", "DescribeScalableTargets", err)
+	if err != nil {
+		return nil, err
+	}
+	if len(targets.ScalableTargets) == 0 {
+		return nil, nil
+	}
+	target := targets.ScalableTargets[0]
+	res := &svcapitypes.ProvisionedConcurrencyAutoscaling{
+		MaxCapacity: int64OrNil(target.MaxCapacity),
+		MinCapacity: int64OrNil(target.MinCapacity),
+	}
+
+	policies, err := client.DescribeScalingPolicies(ctx, &svcsdk.DescribeScalingPoliciesInput{
+		ServiceNamespace:  svcsdktypes.ServiceNamespaceLambda,
+		ScalableDimension: svcsdktypes.ScalableDimensionLambdaFunctionProvisionedConcurrency,
+		ResourceId:        aws.String(resourceID),
+		PolicyNames:       []string{TargetTrackingPolicyName},
+	})
+	mr.RecordAPICall("READ_MANY", "DescribeScalingPolicies", err)
+	if err != nil {
+		return nil, err
+	}
+	for _, policy := range policies.ScalingPolicies {
+		if policy.TargetTrackingScalingPolicyConfiguration != nil {
+			res.TargetUtilization = policy.TargetTrackingScalingPolicyConfiguration.TargetValue
+		}
+	}
+
+	input := &svcsdk.DescribeScheduledActionsInput{
+		ServiceNamespace:  svcsdktypes.ServiceNamespaceLambda,
+		ScalableDimension: svcsdktypes.ScalableDimensionLambdaFunctionProvisionedConcurrency,
+		ResourceId:        aws.String(resourceID),
+	}
+	for {
+		var actions *svcsdk.DescribeScheduledActionsOutput
+		actions, err = client.DescribeScheduledActions(ctx, input)
+		mr.RecordAPICall("READ_MANY", "DescribeScheduledActions", err)
+		if err != nil {
+			return nil, err
+		}
+		for _, action := range actions.ScheduledActions {
+			observed := &svcapitypes.ProvisionedConcurrencyScheduledAction{
+				Name:     action.ScheduledActionName,
+				Schedule: action.Schedule,
+				Timezone: action.Timezone,
+			}
+			if action.ScalableTargetAction != nil {
+				observed.MaxCapacity = int64OrNil(action.ScalableTargetAction.MaxCapacity)
+				observed.MinCapacity = int64OrNil(action.ScalableTargetAction.MinCapacity)
+			}
+			res.ScheduledActions = append(res.ScheduledActions, observed)
+		}
+		if actions.NextToken == nil {
+			break
+		}
+		input.NextToken = actions.NextToken
+	}
+	return res, nil
+}
+
+// ProvisionedConcurrencyStatus returns the scaling range currently in effect
+// and the scheduled action that set it, as observed in the supplied
+// autoscaling configuration.
+func ProvisionedConcurrencyStatus(
+	observed *svcapitypes.ProvisionedConcurrencyAutoscaling,
+	now time.Time,
+) *svcapitypes.ProvisionedConcurrencyStatus {
+	status := &svcapitypes.ProvisionedConcurrencyStatus{}
+	if observed == nil {
+		return status
+	}
+	status.CurrentMaxCapacity = observed.MaxCapacity
+	status.CurrentMinCapacity = observed.MinCapacity
+	if active, since := ActiveScheduledAction(observed.ScheduledActions, now); active != nil {
+		status.ActiveScheduledAction = active.Name
+		status.ActiveSince = &metav1.Time{Time: since}
+	}
+	return status
+}
+
+// NormalizeObserved makes the observed autoscaling configuration comparable
+// with the desired one. Scheduled actions are ordered like the desired ones
+// and the default UTC time zone is dropped when it wasn't requested.
+//
+// Scheduled actions overwrite the scalable target range when they fire, so
+// while the observed range is the one of a scheduled action the desired range
+// is reported instead. Otherwise every firing would be reverted on the next
+// reconciliation.
+func NormalizeObserved(
+	observed *svcapitypes.ProvisionedConcurrencyAutoscaling,
+	desired *svcapitypes.ProvisionedConcurrencyAutoscaling,
+) {
+	if observed == nil || desired == nil {
+		return
+	}
+	desiredActions := map[string]*svcapitypes.ProvisionedConcurrencyScheduledAction{}
+	order := map[string]int{}
+	for i, action := range desired.ScheduledActions {
+		if action != nil && action.Name != nil {
+			desiredActions[*action.Name] = action
+			order[*action.Name] = i
+		}
+	}
+	for _, action := range observed.ScheduledActions {
+		d, ok := desiredActions[aws.ToString(action.Name)]
+		if ok && d.Timezone == nil && aws.ToString(action.Timezone) == "UTC" {
+			action.Timezone = nil
+		}
+	}
+	sort.SliceStable(observed.ScheduledActions, func(i, j int) bool {
+		oi, iok := order[aws.ToString(observed.ScheduledActions[i].Name)]
+		oj, jok := order[aws.ToString(observed.ScheduledActions[j].Name)]
+		if iok != jok {
+			return iok
+		}
+		return oi < oj
+	})
+
+	for _, action := range observed.ScheduledActions {
+		if (action.MinCapacity == nil || int64PtrEquals(action.MinCapacity, observed.MinCapacity)) &&
+			(action.MaxCapacity == nil || int64PtrEquals(action.MaxCapacity, observed.MaxCapacity)) {
+			observed.MinCapacity = desired.MinCapacity
+			observed.MaxCapacity = desired.MaxCapacity
+			return
+		}
+	}
+}
+
+// SyncProvisionedConcurrencyAutoscaling registers, updates or deregisters the
+// provisioned concurrency scalable target and its target tracking policy so
+// that they match the desired configuration.
+func SyncProvisionedConcurrencyAutoscaling(
+	ctx context.Context,
+	client autoscalingClient,
+	mr metricsRecorder,
+	resourceID string,
+	desired *svcapitypes.ProvisionedConcurrencyAutoscaling,
+	latest *svcapitypes.ProvisionedConcurrencyAutoscaling,
+) error {
+	var err error
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("autoscaling.SyncProvisionedConcurrencyAutoscaling")
+	defer func() { exit(err) }()
+
+	if desired == nil {
+		if latest == nil {
+			return nil
+		}
+		err = DeregisterProvisionedConcurrencyAutoscaling(ctx, client, mr, resourceID)
+		return err
+	}
+
+	// Registering the target again resets the range set by the last scheduled
+	// action, so only do it when the desired range changed.
+	if latest == nil || !int64PtrEquals(desired.MinCapacity, latest.MinCapacity) ||
+		!int64PtrEquals(desired.MaxCapacity, latest.MaxCapacity) {
+		_, err = client.RegisterScalableTarget(ctx, &svcsdk.RegisterScalableTargetInput{
+			ServiceNamespace:  svcsdktypes.ServiceNamespaceLambda,
+			ScalableDimension: svcsdktypes.ScalableDimensionLambdaFunctionProvisionedConcurrency,
+			ResourceId:        aws.String(resourceID),
+			MinCapacity:       int32OrNil(desired.MinCapacity),
+			MaxCapacity:       int32OrNil(desired.MaxCapacity),
+		})
+		mr.RecordAPICall("UPDATE", "RegisterScalableTarget", err)
+		if err != nil {
+			return err
+		}
+	}
+
+	var latestActions []*svcapitypes.ProvisionedConcurrencyScheduledAction
+	if latest != nil {
+		latestActions = latest.ScheduledActions
+	}
+	err = syncScheduledActions(ctx, client, mr, resourceID, desired.ScheduledActions, latestActions)
+	if err != nil {
+		return err
+	}
+
+	if desired.TargetUtilization != nil {
+		_, err = client.PutScalingPolicy(ctx, &svcsdk.PutScalingPolicyInput{
+			PolicyName:        aws.String(TargetTrackingPolicyName),
+			PolicyType:        svcsdktypes.PolicyTypeTargetTrackingScaling,
+			ServiceNamespace:  svcsdktypes.ServiceNamespaceLambda,
+			ScalableDimension: svcsdktypes.ScalableDimensionLambdaFunctionProvisionedConcurrency,
+			ResourceId:        aws.String(resourceID),
+			TargetTrackingScalingPolicyConfiguration: &svcsdktypes.TargetTrackingScalingPolicyConfiguration{
+				TargetValue: desired.TargetUtilization,
+				PredefinedMetricSpecification: &svcsdktypes.PredefinedMetricSpecification{
+					PredefinedMetricType: svcsdktypes.MetricTypeLambdaProvisionedConcurrencyUtilization,
+				},
+			},
+		})
+		mr.RecordAPICall("UPDATE", "PutScalingPolicy", err)
+		return err
+	}
+	if latest != nil && latest.TargetUtilization != nil {
+		_, err = client.DeleteScalingPolicy(ctx, &svcsdk.DeleteScalingPolicyInput{
+			PolicyName:        aws.String(TargetTrackingPolicyName),
+			ServiceNamespace:  svcsdktypes.ServiceNamespaceLambda,
+			ScalableDimension: svcsdktypes.ScalableDimensionLambdaFunctionProvisionedConcurrency,
+			ResourceId:        aws.String(resourceID),
+		})
+		mr.RecordAPICall("DELETE", "DeleteScalingPolicy", err)
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+		err = nil
+	}
+	return nil
+}
+
+// syncScheduledActions puts the desired scheduled actions that are missing or
+// changed and deletes the ones that are no longer desired.
+func syncScheduledActions(
+	ctx context.Context,
+	client autoscalingClient,
+	mr metricsRecorder,
+	resourceID string,
+	desired []*svcapitypes.ProvisionedConcurrencyScheduledAction,
+	latest []*svcapitypes.ProvisionedConcurrencyScheduledAction,
+) error {
+	existing := map[string]*svcapitypes.ProvisionedConcurrencyScheduledAction{}
+	for _, action := range latest {
+		existing[aws.ToString(action.Name)] = action
+	}
+	desiredNames := map[string]bool{}
+	for _, action := range desired {
+		name := aws.ToString(action.Name)
+		desiredNames[name] = true
+		if current, ok := existing[name]; ok && scheduledActionEqual(action, current) {
+			continue
+		}
+		_, err := client.PutScheduledAction(ctx, &svcsdk.PutScheduledActionInput{
+			ServiceNamespace:    svcsdktypes.ServiceNamespaceLambda,
+			ScalableDimension:   svcsdktypes.ScalableDimensionLambdaFunctionProvisionedConcurrency,
+			ResourceId:          aws.String(resourceID),
+			ScheduledActionName: action.Name,
+			Schedule:            action.Schedule,
+			Timezone:            action.Timezone,
+			ScalableTargetAction: &svcsdktypes.ScalableTargetAction{
+				MaxCapacity: int32OrNil(action.MaxCapacity),
+				MinCapacity: int32OrNil(action.MinCapacity),
+			},
+		})
+		mr.RecordAPICall("UPDATE", "PutScheduledAction", err)
+		if err != nil {
+			return err
+		}
+	}
+	for name := range existing {
+		if desiredNames[name] {
+			continue
+		}
+		_, err := client.DeleteScheduledAction(ctx, &svcsdk.DeleteScheduledActionInput{
+			ServiceNamespace:    svcsdktypes.ServiceNamespaceLambda,
+			ScalableDimension:   svcsdktypes.ScalableDimensionLambdaFunctionProvisionedConcurrency,
+			ResourceId:          aws.String(resourceID),
+			ScheduledActionName: aws.String(name),
+		})
+		mr.RecordAPICall("DELETE", "DeleteScheduledAction", err)
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func scheduledActionEqual(a, b *svcapitypes.ProvisionedConcurrencyScheduledAction) bool {
+	return aws.ToString(a.Schedule) == aws.ToString(b.Schedule) &&
+		aws.ToString(a.Timezone) == aws.ToString(b.Timezone) &&
+		int64PtrEquals(a.MinCapacity, b.MinCapacity) &&
+		int64PtrEquals(a.MaxCapacity, b.MaxCapacity)
+}
+
+// DeregisterProvisionedConcurrencyAutoscaling deregisters the provisioned
+// concurrency scalable target, which also deletes its scaling policies and
+// scheduled actions.
+func DeregisterProvisionedConcurrencyAutoscaling(
+	ctx context.Context,
+	client autoscalingClient,
+	mr metricsRecorder,
+	resourceID string,
+) error {
+	_, err := client.DeregisterScalableTarget(ctx, &svcsdk.DeregisterScalableTargetInput{
+		ServiceNamespace:  svcsdktypes.ServiceNamespaceLambda,
+		ScalableDimension: svcsdktypes.ScalableDimensionLambdaFunctionProvisionedConcurrency,
+		ResourceId:        aws.String(resourceID),
+	})
+	mr.RecordAPICall("DELETE", "DeregisterScalableTarget", err)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	var notFound *svcsdktypes.ObjectNotFoundException
+	return errors.As(err, &notFound)
+}
+
+func int32OrNil(val *int64) *int32 {
+	if val != nil {
+		return aws.Int32(int32(*val))
+	}
+	return nil
+}
+
+func int64OrNil(val *int32) *int64 {
+	if val != nil {
+		return aws.Int64(int64(*val))
+	}
+	return nil
+}
+
+func int64PtrEquals(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,58 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package autoscaling
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+)
+
+func TestInUse(t *testing.T) {
+	tests := []struct {
+		name    string
+		desired *svcapitypes.ProvisionedConcurrencyAutoscaling
+		status  *svcapitypes.ProvisionedConcurrencyStatus
+		want    bool
+	}{
+		{
+			name: "never configured",
+			want: false,
+		},
+		{
+			name:   "provisioned concurrency without autoscaling",
+			status: &svcapitypes.ProvisionedConcurrencyStatus{AllocatedProvisionedConcurrentExecutions: aws.Int64(5)},
+			want:   false,
+		},
+		{
+			name:    "desired",
+			desired: &svcapitypes.ProvisionedConcurrencyAutoscaling{MaxCapacity: aws.Int64(10)},
+			want:    true,
+		},
+		{
+			name:   "removed from the spec while registered",
+			status: &svcapitypes.ProvisionedConcurrencyStatus{CurrentMaxCapacity: aws.Int64(10)},
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InUse(tt.desired, tt.status); got != tt.want {
+				t.Errorf("InUse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package event_source_mapping
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/lambda"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+
+	"github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+)
+
+// ownerTagKey is the tag recording the namespace and name of the resource
+// that created an event source mapping. It is set when the mapping is
+// created, and is the only way to tell apart mappings of several resources
+// sharing a function and an event source. The tag is left out of the tags
+// read back, so that it is never removed.
+const ownerTagKey = "lambda.services.k8s.aws/event-source-mapping"
+
+// creationClockSkew is how much earlier than the resource an event source
+// mapping created for it may appear to have been last modified, the two
+// timestamps coming from different clocks.
+const creationClockSkew = 5 * time.Minute
+
+var (
+	ErrAmbiguousEventSourceMapping = errors.New("several existing event source mappings match the function and event source, refusing to create another one")
+)
+
+// findExistingMapping looks up the event source mapping of a resource whose
+// UUID is unknown, which happens when the controller stopped after
+// CreateEventSourceMapping succeeded but before the UUID was saved in the
+// status. Mappings are listed by function and event source ARN, and only the
+// ones created for this resource, as recorded by their owner tag, and
+// matching its immutable fields and desired tags, are considered. A single
+// match is adopted by returning a copy of the resource with its UUID set.
+// Several matches return a terminal error, rather than creating yet another
+// mapping consuming the same event source.
+//
+// Mappings created before the owner tag existed carry no owner tag. They
+// are only adopted when the resource has an adoption policy annotation, on
+// their immutable fields alone, and only if no mapping owned by the
+// resource matches.
+//
+// Without an adoption policy annotation, nothing is looked up until the
+// resource is managed, as its finalizer is added before the mapping is
+// created, and the tags of mappings last modified before the resource was
+// created are never read.
+//
+// Mappings without an event source ARN, such as self-managed Apache Kafka
+// ones, can't be told apart and are never looked up.
+//
+// This function is used as a sdk_read_one_pre_build_request hook.
+func (rm *resourceManager) findExistingMapping(
+	ctx context.Context,
+	r *resource,
+) (*resource, error) {
+	spec := r.ko.Spec
+	if spec.FunctionName == nil || *spec.FunctionName == "" ||
+		spec.EventSourceARN == nil || *spec.EventSourceARN == "" {
+		return nil, ackerr.NotFound
+	}
+	explicit := adoptionRequested(r.ko)
+	if !explicit && !(&resourceDescriptor{}).IsManaged(r) {
+		return nil, ackerr.NotFound
+	}
+
+	var err error
+	owned := []string{}
+	untagged := []string{}
+	input := &svcsdk.ListEventSourceMappingsInput{
+		FunctionName:   spec.FunctionName,
+		EventSourceArn: spec.EventSourceARN,
+	}
+	for {
+		var resp *svcsdk.ListEventSourceMappingsOutput
+		resp, err = rm.sdkapi.ListEventSourceMappings(ctx, input)
+		rm.metrics.RecordAPICall("READ_MANY", "ListEventSourceMappings", err)
+		if err != nil {
+			return nil, err
+		}
+		for _, mapping := range resp.EventSourceMappings {
+			if !isCandidateMapping(r.ko, mapping, explicit) {
+				continue
+			}
+			tags, err := rm.getTags(ctx, *mapping.EventSourceMappingArn)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := tags[ownerTagKey]; !ok {
+				if explicit {
+					untagged = append(untagged, *mapping.UUID)
+				}
+				continue
+			}
+			if isOwnedMapping(r.ko, tags) {
+				owned = append(owned, *mapping.UUID)
+			}
+		}
+		if resp.NextMarker == nil {
+			break
+		}
+		input.Marker = resp.NextMarker
+	}
+
+	matches := owned
+	if len(matches) == 0 {
+		matches = untagged
+	}
+	switch len(matches) {
+	case 0:
+		return nil, ackerr.NotFound
+	case 1:
+		rlog := ackrtlog.FromContext(ctx)
+		rlog.Info("adopting existing event source mapping", "uuid", matches[0])
+		ko := r.ko.DeepCopy()
+		ko.Status.UUID = aws.String(matches[0])
+		return &resource{ko}, nil
+	default:
+		return nil, ackerr.NewTerminalError(fmt.Errorf(
+			"%w: found %s. Delete the extra mappings or adopt one of them explicitly.",
+			ErrAmbiguousEventSourceMapping, strings.Join(matches, ", "),
+		))
+	}
+}
+
+// adoptionRequested returns true if the resource has an adoption policy
+// annotation.
+func adoptionRequested(ko *v1alpha1.EventSourceMapping) bool {
+	_, ok := ko.GetAnnotations()[ackv1alpha1.AnnotationAdoptionPolicy]
+	return ok
+}
+
+// isCandidateMapping returns true if an existing event source mapping may
+// have been created for the supplied resource, from what was listed alone:
+// it isn't being deleted and reads from the same topics, queues and starting
+// position. Unless explicit adoption was requested, it must also have been
+// last modified after the resource was created.
+func isCandidateMapping(
+	ko *v1alpha1.EventSourceMapping,
+	mapping svcsdktypes.EventSourceMappingConfiguration,
+	explicit bool,
+) bool {
+	if mapping.UUID == nil || mapping.EventSourceMappingArn == nil {
+		return false
+	}
+	if mapping.State != nil && *mapping.State == stateDeleting {
+		return false
+	}
+	if !explicit && mapping.LastModified != nil && !ko.CreationTimestamp.IsZero() &&
+		mapping.LastModified.Before(ko.CreationTimestamp.Add(-creationClockSkew)) {
+		return false
+	}
+	return matchesImmutableFields(ko, mapping)
+}
+
+// isOwnedMapping returns true if the tags of an existing event source
+// mapping record that it was created for the supplied resource, and include
+// all of the desired tags.
+func isOwnedMapping(ko *v1alpha1.EventSourceMapping, tags map[string]*string) bool {
+	if owner, ok := tags[ownerTagKey]; !ok || !equalStrings(owner, aws.String(ownerTagValue(ko))) {
+		return false
+	}
+	for k, v := range ko.Spec.Tags {
+		if actual, ok := tags[k]; !ok || !equalStrings(v, actual) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesImmutableFields returns true if an existing event source mapping
+// has the same topics, queues, self-managed event source and starting
+// position as the supplied resource. None of them can be updated.
+func matchesImmutableFields(
+	ko *v1alpha1.EventSourceMapping,
+	mapping svcsdktypes.EventSourceMappingConfiguration,
+) bool {
+	if !equalStringLists(ko.Spec.Topics, mapping.Topics) ||
+		!equalStringLists(ko.Spec.Queues, mapping.Queues) ||
+		aws.ToString(ko.Spec.StartingPosition) != string(mapping.StartingPosition) {
+		return false
+	}
+	var endpoints map[string][]*string
+	if ko.Spec.SelfManagedEventSource != nil {
+		endpoints = ko.Spec.SelfManagedEventSource.Endpoints
+	}
+	var mappingEndpoints map[string][]string
+	if mapping.SelfManagedEventSource != nil {
+		mappingEndpoints = mapping.SelfManagedEventSource.Endpoints
+	}
+	if len(endpoints) != len(mappingEndpoints) {
+		return false
+	}
+	for k, v := range endpoints {
+		actual, ok := mappingEndpoints[k]
+		if !ok || !equalStringLists(v, actual) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalStringLists returns whether a list of the resource's spec and a list
+// returned by Lambda hold the same strings, in the same order.
+func equalStringLists(a []*string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if aws.ToString(a[i]) != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ownerTagValue returns the value of the owner tag of the event source
+// mappings created for the supplied resource.
+func ownerTagValue(ko *v1alpha1.EventSourceMapping) string {
+	return ko.GetNamespace() + "/" + ko.GetName()
+}
+
+// setOwnerTag adds the owner tag to a CreateEventSourceMapping request.
+//
+// This function is used as a sdk_create_post_build_request hook.
+func setOwnerTag(input *svcsdk.CreateEventSourceMappingInput, ko *v1alpha1.EventSourceMapping) {
+	if input.Tags == nil {
+		input.Tags = map[string]string{}
+	}
+	input.Tags[ownerTagKey] = ownerTagValue(ko)
+}
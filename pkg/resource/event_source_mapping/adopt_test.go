@@ -0,0 +1,300 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package event_source_mapping
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/lambda"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+)
+
+func Test_matchesImmutableFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    v1alpha1.EventSourceMappingSpec
+		mapping svcsdktypes.EventSourceMappingConfiguration
+		want    bool
+	}{
+		{
+			name:    "no immutable fields",
+			spec:    v1alpha1.EventSourceMappingSpec{},
+			mapping: svcsdktypes.EventSourceMappingConfiguration{},
+			want:    true,
+		},
+		{
+			name: "same topics and starting position",
+			spec: v1alpha1.EventSourceMappingSpec{
+				Topics:           aws.StringSlice([]string{"orders"}),
+				StartingPosition: aws.String("LATEST"),
+			},
+			mapping: svcsdktypes.EventSourceMappingConfiguration{
+				Topics:           []string{"orders"},
+				StartingPosition: svcsdktypes.EventSourcePositionLatest,
+			},
+			want: true,
+		},
+		{
+			name:    "other topic",
+			spec:    v1alpha1.EventSourceMappingSpec{Topics: aws.StringSlice([]string{"orders"})},
+			mapping: svcsdktypes.EventSourceMappingConfiguration{Topics: []string{"payments"}},
+			want:    false,
+		},
+		{
+			name:    "other queue",
+			spec:    v1alpha1.EventSourceMappingSpec{Queues: aws.StringSlice([]string{"orders"})},
+			mapping: svcsdktypes.EventSourceMappingConfiguration{},
+			want:    false,
+		},
+		{
+			name:    "other starting position",
+			spec:    v1alpha1.EventSourceMappingSpec{StartingPosition: aws.String("LATEST")},
+			mapping: svcsdktypes.EventSourceMappingConfiguration{StartingPosition: svcsdktypes.EventSourcePositionTrimHorizon},
+			want:    false,
+		},
+		{
+			name: "other self-managed brokers",
+			spec: v1alpha1.EventSourceMappingSpec{
+				SelfManagedEventSource: &v1alpha1.SelfManagedEventSource{
+					Endpoints: map[string][]*string{"KAFKA_BOOTSTRAP_SERVERS": aws.StringSlice([]string{"broker-1:9092"})},
+				},
+			},
+			mapping: svcsdktypes.EventSourceMappingConfiguration{
+				SelfManagedEventSource: &svcsdktypes.SelfManagedEventSource{
+					Endpoints: map[string][]string{"KAFKA_BOOTSTRAP_SERVERS": {"broker-2:9092"}},
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ko := &v1alpha1.EventSourceMapping{Spec: tt.spec}
+			if got := matchesImmutableFields(ko, tt.mapping); got != tt.want {
+				t.Errorf("matchesImmutableFields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeMapping is an existing event source mapping served by fakeLambda.
+type fakeMapping struct {
+	uuid         string
+	lastModified time.Time
+	tags         map[string]string
+}
+
+// fakeLambda answers ListEventSourceMappings and ListTags requests from a
+// list of mappings, and counts the ListTags requests.
+type fakeLambda struct {
+	mappings   []fakeMapping
+	tagLookups int
+}
+
+func (f *fakeLambda) Do(req *http.Request) (*http.Response, error) {
+	var body any
+	if _, arn, ok := strings.Cut(req.URL.Path, "/tags/"); ok {
+		f.tagLookups++
+		for _, m := range f.mappings {
+			if mappingARN(m.uuid) == arn {
+				body = map[string]any{"Tags": m.tags}
+			}
+		}
+	} else {
+		mappings := []map[string]any{}
+		for _, m := range f.mappings {
+			mappings = append(mappings, map[string]any{
+				"UUID":                  m.uuid,
+				"EventSourceMappingArn": mappingARN(m.uuid),
+				"State":                 "Enabled",
+				"LastModified":          m.lastModified.Unix(),
+			})
+		}
+		body = map[string]any{"EventSourceMappings": mappings}
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Content-Type":     []string{"application/json"},
+			"X-Amzn-Requestid": []string{"test"},
+		},
+		Body:    io.NopCloser(strings.NewReader(string(b))),
+		Request: req,
+	}, nil
+}
+
+func mappingARN(uuid string) string {
+	return "arn:aws:lambda:us-west-2:123456789012:event-source-mapping:" + uuid
+}
+
+func Test_findExistingMapping(t *testing.T) {
+	created := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	owned := map[string]string{ownerTagKey: "default/orders"}
+	otherOwner := map[string]string{ownerTagKey: "default/payments"}
+	tests := []struct {
+		name           string
+		managed        bool
+		annotations    map[string]string
+		mappings       []fakeMapping
+		wantUUID       string
+		wantAmbiguous  bool
+		wantTagLookups int
+	}{
+		{
+			name:     "not managed yet",
+			mappings: []fakeMapping{{uuid: "a", lastModified: created, tags: owned}},
+		},
+		{
+			name:           "single owned mapping",
+			managed:        true,
+			mappings:       []fakeMapping{{uuid: "a", lastModified: created, tags: owned}},
+			wantUUID:       "a",
+			wantTagLookups: 1,
+		},
+		{
+			name:    "several owned mappings",
+			managed: true,
+			mappings: []fakeMapping{
+				{uuid: "a", lastModified: created, tags: owned},
+				{uuid: "b", lastModified: created.Add(time.Minute), tags: owned},
+			},
+			wantAmbiguous:  true,
+			wantTagLookups: 2,
+		},
+		{
+			name:    "mapping of another resource",
+			managed: true,
+			mappings: []fakeMapping{
+				{uuid: "a", lastModified: created, tags: otherOwner},
+			},
+			wantTagLookups: 1,
+		},
+		{
+			name:    "mapping older than the resource",
+			managed: true,
+			mappings: []fakeMapping{
+				{uuid: "a", lastModified: created.Add(-time.Hour), tags: owned},
+			},
+		},
+		{
+			name:    "untagged mapping",
+			managed: true,
+			mappings: []fakeMapping{
+				{uuid: "a", lastModified: created},
+			},
+			wantTagLookups: 1,
+		},
+		{
+			name:        "untagged mapping with an adoption policy",
+			annotations: map[string]string{ackv1alpha1.AnnotationAdoptionPolicy: "adopt-or-create"},
+			mappings: []fakeMapping{
+				{uuid: "a", lastModified: created.Add(-time.Hour)},
+				{uuid: "b", lastModified: created.Add(-time.Hour), tags: otherOwner},
+			},
+			wantUUID:       "a",
+			wantTagLookups: 2,
+		},
+		{
+			name:        "owned and untagged mappings with an adoption policy",
+			annotations: map[string]string{ackv1alpha1.AnnotationAdoptionPolicy: "adopt-or-create"},
+			mappings: []fakeMapping{
+				{uuid: "a", lastModified: created.Add(-time.Hour)},
+				{uuid: "b", lastModified: created, tags: owned},
+			},
+			wantUUID:       "b",
+			wantTagLookups: 2,
+		},
+		{
+			name:        "several untagged mappings with an adoption policy",
+			annotations: map[string]string{ackv1alpha1.AnnotationAdoptionPolicy: "adopt-or-create"},
+			mappings: []fakeMapping{
+				{uuid: "a", lastModified: created.Add(-time.Hour)},
+				{uuid: "b", lastModified: created.Add(-time.Hour)},
+			},
+			wantAmbiguous:  true,
+			wantTagLookups: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lambda := &fakeLambda{mappings: tt.mappings}
+			rm := &resourceManager{
+				metrics: ackmetrics.NewMetrics("lambda"),
+				sdkapi: svcsdk.New(svcsdk.Options{
+					Region:           "us-west-2",
+					Credentials:      aws.AnonymousCredentials{},
+					HTTPClient:       lambda,
+					RetryMaxAttempts: 1,
+				}),
+			}
+			ko := &v1alpha1.EventSourceMapping{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "orders",
+					Namespace:         "default",
+					CreationTimestamp: metav1.NewTime(created),
+					Annotations:       tt.annotations,
+				},
+				Spec: v1alpha1.EventSourceMappingSpec{
+					FunctionName:   aws.String("my-function"),
+					EventSourceARN: aws.String("arn:aws:sqs:us-west-2:123456789012:orders"),
+				},
+			}
+			r := &resource{ko}
+			if tt.managed {
+				(&resourceDescriptor{}).MarkManaged(r)
+			}
+
+			found, err := rm.findExistingMapping(context.Background(), r)
+			switch {
+			case tt.wantAmbiguous:
+				var terminalErr *ackerr.TerminalError
+				if !errors.As(err, &terminalErr) || !errors.Is(err, ErrAmbiguousEventSourceMapping) {
+					t.Errorf("expected a terminal ErrAmbiguousEventSourceMapping, got %v", err)
+				}
+			case tt.wantUUID == "":
+				if err != ackerr.NotFound {
+					t.Errorf("expected NotFound, got %v", err)
+				}
+			default:
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := aws.ToString(found.ko.Status.UUID); got != tt.wantUUID {
+					t.Errorf("adopted %q, want %q", got, tt.wantUUID)
+				}
+			}
+			if lambda.tagLookups != tt.wantTagLookups {
+				t.Errorf("got %d ListTags calls, want %d", lambda.tagLookups, tt.wantTagLookups)
+			}
+		})
+	}
+}
@@ -126,6 +126,31 @@ func newResourceDelta(
 			}
 		}
 	}
+	if ackcompare.HasNilDifference(a.ko.Spec.DocumentDBEventSourceConfig, b.ko.Spec.DocumentDBEventSourceConfig) {
+		delta.Add("Spec.DocumentDBEventSourceConfig", a.ko.Spec.DocumentDBEventSourceConfig, b.ko.Spec.DocumentDBEventSourceConfig)
+	} else if a.ko.Spec.DocumentDBEventSourceConfig != nil && b.ko.Spec.DocumentDBEventSourceConfig != nil {
+		if ackcompare.HasNilDifference(a.ko.Spec.DocumentDBEventSourceConfig.CollectionName, b.ko.Spec.DocumentDBEventSourceConfig.CollectionName) {
+			delta.Add("Spec.DocumentDBEventSourceConfig.CollectionName", a.ko.Spec.DocumentDBEventSourceConfig.CollectionName, b.ko.Spec.DocumentDBEventSourceConfig.CollectionName)
+		} else if a.ko.Spec.DocumentDBEventSourceConfig.CollectionName != nil && b.ko.Spec.DocumentDBEventSourceConfig.CollectionName != nil {
+			if *a.ko.Spec.DocumentDBEventSourceConfig.CollectionName != *b.ko.Spec.DocumentDBEventSourceConfig.CollectionName {
+				delta.Add("Spec.DocumentDBEventSourceConfig.CollectionName", a.ko.Spec.DocumentDBEventSourceConfig.CollectionName, b.ko.Spec.DocumentDBEventSourceConfig.CollectionName)
+			}
+		}
+		if ackcompare.HasNilDifference(a.ko.Spec.DocumentDBEventSourceConfig.DatabaseName, b.ko.Spec.DocumentDBEventSourceConfig.DatabaseName) {
+			delta.Add("Spec.DocumentDBEventSourceConfig.DatabaseName", a.ko.Spec.DocumentDBEventSourceConfig.DatabaseName, b.ko.Spec.DocumentDBEventSourceConfig.DatabaseName)
+		} else if a.ko.Spec.DocumentDBEventSourceConfig.DatabaseName != nil && b.ko.Spec.DocumentDBEventSourceConfig.DatabaseName != nil {
+			if *a.ko.Spec.DocumentDBEventSourceConfig.DatabaseName != *b.ko.Spec.DocumentDBEventSourceConfig.DatabaseName {
+				delta.Add("Spec.DocumentDBEventSourceConfig.DatabaseName", a.ko.Spec.DocumentDBEventSourceConfig.DatabaseName, b.ko.Spec.DocumentDBEventSourceConfig.DatabaseName)
+			}
+		}
+		if ackcompare.HasNilDifference(a.ko.Spec.DocumentDBEventSourceConfig.FullDocument, b.ko.Spec.DocumentDBEventSourceConfig.FullDocument) {
+			delta.Add("Spec.DocumentDBEventSourceConfig.FullDocument", a.ko.Spec.DocumentDBEventSourceConfig.FullDocument, b.ko.Spec.DocumentDBEventSourceConfig.FullDocument)
+		} else if a.ko.Spec.DocumentDBEventSourceConfig.FullDocument != nil && b.ko.Spec.DocumentDBEventSourceConfig.FullDocument != nil {
+			if *a.ko.Spec.DocumentDBEventSourceConfig.FullDocument != *b.ko.Spec.DocumentDBEventSourceConfig.FullDocument {
+				delta.Add("Spec.DocumentDBEventSourceConfig.FullDocument", a.ko.Spec.DocumentDBEventSourceConfig.FullDocument, b.ko.Spec.DocumentDBEventSourceConfig.FullDocument)
+			}
+		}
+	}
 	if ackcompare.HasNilDifference(a.ko.Spec.Enabled, b.ko.Spec.Enabled) {
 		delta.Add("Spec.Enabled", a.ko.Spec.Enabled, b.ko.Spec.Enabled)
 	} else if a.ko.Spec.Enabled != nil && b.ko.Spec.Enabled != nil {
@@ -160,6 +185,16 @@ func newResourceDelta(
 			delta.Add("Spec.FunctionResponseTypes", a.ko.Spec.FunctionResponseTypes, b.ko.Spec.FunctionResponseTypes)
 		}
 	}
+	if ackcompare.HasNilDifference(a.ko.Spec.KMSKeyARN, b.ko.Spec.KMSKeyARN) {
+		delta.Add("Spec.KMSKeyARN", a.ko.Spec.KMSKeyARN, b.ko.Spec.KMSKeyARN)
+	} else if a.ko.Spec.KMSKeyARN != nil && b.ko.Spec.KMSKeyARN != nil {
+		if *a.ko.Spec.KMSKeyARN != *b.ko.Spec.KMSKeyARN {
+			delta.Add("Spec.KMSKeyARN", a.ko.Spec.KMSKeyARN, b.ko.Spec.KMSKeyARN)
+		}
+	}
+	if !equality.Semantic.Equalities.DeepEqual(a.ko.Spec.KMSKeyRef, b.ko.Spec.KMSKeyRef) {
+		delta.Add("Spec.KMSKeyRef", a.ko.Spec.KMSKeyRef, b.ko.Spec.KMSKeyRef)
+	}
 	if ackcompare.HasNilDifference(a.ko.Spec.LoggingConfig, b.ko.Spec.LoggingConfig) {
 		delta.Add("Spec.LoggingConfig", a.ko.Spec.LoggingConfig, b.ko.Spec.LoggingConfig)
 	} else if a.ko.Spec.LoggingConfig != nil && b.ko.Spec.LoggingConfig != nil {
@@ -199,6 +234,31 @@ func newResourceDelta(
 			delta.Add("Spec.ParallelizationFactor", a.ko.Spec.ParallelizationFactor, b.ko.Spec.ParallelizationFactor)
 		}
 	}
+	if ackcompare.HasNilDifference(a.ko.Spec.ProvisionedPollerConfig, b.ko.Spec.ProvisionedPollerConfig) {
+		delta.Add("Spec.ProvisionedPollerConfig", a.ko.Spec.ProvisionedPollerConfig, b.ko.Spec.ProvisionedPollerConfig)
+	} else if a.ko.Spec.ProvisionedPollerConfig != nil && b.ko.Spec.ProvisionedPollerConfig != nil {
+		if ackcompare.HasNilDifference(a.ko.Spec.ProvisionedPollerConfig.MaximumPollers, b.ko.Spec.ProvisionedPollerConfig.MaximumPollers) {
+			delta.Add("Spec.ProvisionedPollerConfig.MaximumPollers", a.ko.Spec.ProvisionedPollerConfig.MaximumPollers, b.ko.Spec.ProvisionedPollerConfig.MaximumPollers)
+		} else if a.ko.Spec.ProvisionedPollerConfig.MaximumPollers != nil && b.ko.Spec.ProvisionedPollerConfig.MaximumPollers != nil {
+			if *a.ko.Spec.ProvisionedPollerConfig.MaximumPollers != *b.ko.Spec.ProvisionedPollerConfig.MaximumPollers {
+				delta.Add("Spec.ProvisionedPollerConfig.MaximumPollers", a.ko.Spec.ProvisionedPollerConfig.MaximumPollers, b.ko.Spec.ProvisionedPollerConfig.MaximumPollers)
+			}
+		}
+		if ackcompare.HasNilDifference(a.ko.Spec.ProvisionedPollerConfig.MinimumPollers, b.ko.Spec.ProvisionedPollerConfig.MinimumPollers) {
+			delta.Add("Spec.ProvisionedPollerConfig.MinimumPollers", a.ko.Spec.ProvisionedPollerConfig.MinimumPollers, b.ko.Spec.ProvisionedPollerConfig.MinimumPollers)
+		} else if a.ko.Spec.ProvisionedPollerConfig.MinimumPollers != nil && b.ko.Spec.ProvisionedPollerConfig.MinimumPollers != nil {
+			if *a.ko.Spec.ProvisionedPollerConfig.MinimumPollers != *b.ko.Spec.ProvisionedPollerConfig.MinimumPollers {
+				delta.Add("Spec.ProvisionedPollerConfig.MinimumPollers", a.ko.Spec.ProvisionedPollerConfig.MinimumPollers, b.ko.Spec.ProvisionedPollerConfig.MinimumPollers)
+			}
+		}
+		if ackcompare.HasNilDifference(a.ko.Spec.ProvisionedPollerConfig.PollerGroupName, b.ko.Spec.ProvisionedPollerConfig.PollerGroupName) {
+			delta.Add("Spec.ProvisionedPollerConfig.PollerGroupName", a.ko.Spec.ProvisionedPollerConfig.PollerGroupName, b.ko.Spec.ProvisionedPollerConfig.PollerGroupName)
+		} else if a.ko.Spec.ProvisionedPollerConfig.PollerGroupName != nil && b.ko.Spec.ProvisionedPollerConfig.PollerGroupName != nil {
+			if *a.ko.Spec.ProvisionedPollerConfig.PollerGroupName != *b.ko.Spec.ProvisionedPollerConfig.PollerGroupName {
+				delta.Add("Spec.ProvisionedPollerConfig.PollerGroupName", a.ko.Spec.ProvisionedPollerConfig.PollerGroupName, b.ko.Spec.ProvisionedPollerConfig.PollerGroupName)
+			}
+		}
+	}
 	if !equality.Semantic.Equalities.DeepEqual(a.ko.Spec.QueueRefs, b.ko.Spec.QueueRefs) {
 		delta.Add("Spec.QueueRefs", a.ko.Spec.QueueRefs, b.ko.Spec.QueueRefs)
 	}
@@ -274,13 +334,6 @@ func newResourceDelta(
 			}
 		}
 	}
-	if len(a.ko.Spec.SourceAccessConfigurations) != len(b.ko.Spec.SourceAccessConfigurations) {
-		delta.Add("Spec.SourceAccessConfigurations", a.ko.Spec.SourceAccessConfigurations, b.ko.Spec.SourceAccessConfigurations)
-	} else if len(a.ko.Spec.SourceAccessConfigurations) > 0 {
-		if !equality.Semantic.Equalities.DeepEqual(a.ko.Spec.SourceAccessConfigurations, b.ko.Spec.SourceAccessConfigurations) {
-			delta.Add("Spec.SourceAccessConfigurations", a.ko.Spec.SourceAccessConfigurations, b.ko.Spec.SourceAccessConfigurations)
-		}
-	}
 	if ackcompare.HasNilDifference(a.ko.Spec.StartingPosition, b.ko.Spec.StartingPosition) {
 		delta.Add("Spec.StartingPosition", a.ko.Spec.StartingPosition, b.ko.Spec.StartingPosition)
 	} else if a.ko.Spec.StartingPosition != nil && b.ko.Spec.StartingPosition != nil {
@@ -0,0 +1,217 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package event_source_mapping
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/lambda-controller/pkg/resource/eventfilter"
+)
+
+// ConditionTypeFilterCriteriaError is raised on an EventSourceMapping whose
+// filter criteria Lambda failed to encrypt or decrypt. The condition reason
+// is the KMS exception reported by Lambda.
+const ConditionTypeFilterCriteriaError ackv1alpha1.ConditionType = "FilterCriteriaError"
+
+// ConditionTypeFilterCriteriaHidden is raised on an EventSourceMapping whose
+// filter criteria are encrypted with a customer managed key that the
+// controller isn't allowed to decrypt with. Lambda doesn't return such filter
+// criteria, so changes to them aren't detected.
+const ConditionTypeFilterCriteriaHidden ackv1alpha1.ConditionType = "FilterCriteriaHidden"
+
+var (
+	errFilterPatternNotObject = errors.New("pattern must be a JSON object")
+	errFilterPatternAndRules  = errors.New("pattern and rules can't both be set")
+)
+
+// validateFilterCriteria returns an error naming the first filter whose
+// pattern isn't a valid JSON object, or whose rules can't be compiled.
+// Lambda would reject it anyway, this avoids sending the request and
+// retrying it forever.
+func validateFilterCriteria(ko *v1alpha1.EventSourceMapping) error {
+	if ko.Spec.FilterCriteria == nil {
+		return nil
+	}
+	for i, filter := range ko.Spec.FilterCriteria.Filters {
+		if filter == nil {
+			continue
+		}
+		if filter.Pattern != nil && filter.Rules != nil {
+			return fmt.Errorf("invalid spec.filterCriteria.filters[%d]: %w", i, errFilterPatternAndRules)
+		}
+		if filter.Rules != nil {
+			if _, err := eventfilter.Compile(filter.Rules); err != nil {
+				return fmt.Errorf("invalid spec.filterCriteria.filters[%d].%w", i, err)
+			}
+			continue
+		}
+		if filter.Pattern == nil {
+			continue
+		}
+		if _, err := parseFilterPattern(*filter.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern in spec.filterCriteria.filters[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// filterPattern returns the pattern of a filter, compiling its rules when
+// they are set.
+func filterPattern(filter *v1alpha1.Filter) (*string, error) {
+	if filter.Rules == nil {
+		return filter.Pattern, nil
+	}
+	pattern, err := eventfilter.Compile(filter.Rules)
+	if err != nil {
+		return nil, err
+	}
+	return &pattern, nil
+}
+
+// setFilterPatterns sets the pattern of the request filters built from
+// rules. The request filters are in the same order as the desired ones.
+//
+// This function is used as a sdk_create_post_build_request and
+// sdk_update_post_build_request hook.
+func setFilterPatterns(
+	input *svcsdktypes.FilterCriteria,
+	desired *v1alpha1.EventSourceMapping,
+) error {
+	if input == nil || desired.Spec.FilterCriteria == nil {
+		return nil
+	}
+	for i, filter := range desired.Spec.FilterCriteria.Filters {
+		if filter == nil || filter.Rules == nil || i >= len(input.Filters) {
+			continue
+		}
+		pattern, err := filterPattern(filter)
+		if err != nil {
+			return fmt.Errorf("invalid spec.filterCriteria.filters[%d].%w", i, err)
+		}
+		input.Filters[i].Pattern = pattern
+	}
+	return nil
+}
+
+// restoreFilterRules puts the desired filter rules back in place of the
+// patterns returned by Lambda, when they compile to the same pattern. This
+// keeps the rules in the spec instead of the pattern they were compiled to.
+//
+// This function is used as a sdk_read_one_post_set_output,
+// sdk_create_post_set_output and sdk_update_post_set_output hook.
+func restoreFilterRules(
+	desired *v1alpha1.EventSourceMapping,
+	ko *v1alpha1.EventSourceMapping,
+) {
+	if desired.Spec.FilterCriteria == nil || ko.Spec.FilterCriteria == nil {
+		return
+	}
+	observed := ko.Spec.FilterCriteria.Filters
+	for i, filter := range desired.Spec.FilterCriteria.Filters {
+		if filter == nil || filter.Rules == nil || i >= len(observed) || observed[i] == nil {
+			continue
+		}
+		pattern, err := filterPattern(filter)
+		if err != nil || !equalFilterPatterns(pattern, observed[i].Pattern) {
+			continue
+		}
+		observed[i] = filter.DeepCopy()
+	}
+}
+
+// parseFilterPattern decodes a filter pattern into its generic JSON
+// representation.
+func parseFilterPattern(pattern string) (map[string]interface{}, error) {
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(pattern), &parsed); err != nil {
+		return nil, err
+	}
+	object, ok := parsed.(map[string]interface{})
+	if !ok {
+		return nil, errFilterPatternNotObject
+	}
+	return object, nil
+}
+
+// equalFilterPatterns returns whether two filter patterns are the same
+// JSON document. Lambda doesn't return patterns byte for byte as they were
+// submitted, so whitespace and key ordering are not significant. Patterns
+// that can't be parsed are compared as plain strings.
+func equalFilterPatterns(a, b *string) bool {
+	if a == nil || b == nil || *a == "" || *b == "" {
+		return equalStrings(a, b)
+	}
+	aParsed, aErr := parseFilterPattern(*a)
+	bParsed, bErr := parseFilterPattern(*b)
+	if aErr != nil || bErr != nil {
+		return *a == *b
+	}
+	return reflect.DeepEqual(aParsed, bParsed)
+}
+
+// setFilterCriteriaErrorCondition sets the FilterCriteriaError condition of
+// an event source mapping: True while Lambda reports a filter criteria error,
+// False otherwise.
+//
+// This function is used as a sdk_read_one_post_set_output,
+// sdk_create_post_set_output and sdk_update_post_set_output hook.
+func setFilterCriteriaErrorCondition(r *resource) {
+	filterErr := r.ko.Status.FilterCriteriaError
+	if filterErr == nil {
+		setCondition(r, ConditionTypeFilterCriteriaError, corev1.ConditionFalse,
+			"NoFilterCriteriaError", "Lambda doesn't report a filter criteria error")
+		return
+	}
+	reason := "FilterCriteriaError"
+	if filterErr.ErrorCode != nil && *filterErr.ErrorCode != "" {
+		reason = *filterErr.ErrorCode
+	}
+	message := "Lambda reported a filter criteria error"
+	if filterErr.Message != nil && *filterErr.Message != "" {
+		message = *filterErr.Message
+	}
+	setCondition(r, ConditionTypeFilterCriteriaError, corev1.ConditionTrue, reason, message)
+}
+
+// setFilterCriteriaHiddenCondition sets the FilterCriteriaHidden condition of
+// an event source mapping encrypting its filter criteria with a customer
+// managed key: True while the desired filter criteria can't be read back,
+// False otherwise.
+//
+// This function is used as a sdk_read_one_post_set_output hook.
+func setFilterCriteriaHiddenCondition(desired *resource, latest *resource) {
+	if latest.ko.Spec.KMSKeyARN == nil || *latest.ko.Spec.KMSKeyARN == "" {
+		return
+	}
+	if desired.ko.Spec.FilterCriteria == nil ||
+		len(desired.ko.Spec.FilterCriteria.Filters) == 0 ||
+		!filterCriteriaHidden(latest) {
+		setCondition(latest, ConditionTypeFilterCriteriaHidden, corev1.ConditionFalse,
+			"FilterCriteriaReadable", "the filter criteria can be read back")
+		return
+	}
+	setCondition(latest, ConditionTypeFilterCriteriaHidden, corev1.ConditionTrue,
+		"FilterCriteriaEncrypted", fmt.Sprintf(
+			"Lambda didn't return the filter criteria encrypted with %s, allow the controller to kms:Decrypt with the key for changes to them to be detected",
+			*latest.ko.Spec.KMSKeyARN,
+		))
+}
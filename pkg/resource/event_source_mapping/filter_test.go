@@ -0,0 +1,220 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package event_source_mapping
+
+import (
+	"strings"
+	"testing"
+
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+)
+
+func Test_equalFilterPatterns(t *testing.T) {
+	tests := []struct {
+		name string
+		a    *string
+		b    *string
+		want bool
+	}{
+		{name: "both nil", want: true},
+		{name: "nil and empty", b: aws.String(""), want: true},
+		{name: "nil and pattern", b: aws.String(`{"body":{"a":[1]}}`), want: false},
+		{
+			name: "whitespace",
+			a:    aws.String(`{"body": {"a": [1]}}`),
+			b:    aws.String(`{"body":{"a":[1]}}`),
+			want: true,
+		},
+		{
+			name: "key ordering",
+			a:    aws.String(`{"body":{"a":["x"],"b":["y"]}}`),
+			b:    aws.String(`{"body":{"b":["y"],"a":["x"]}}`),
+			want: true,
+		},
+		{
+			name: "different values",
+			a:    aws.String(`{"body":{"a":["x"]}}`),
+			b:    aws.String(`{"body":{"a":["y"]}}`),
+			want: false,
+		},
+		{
+			name: "invalid patterns",
+			a:    aws.String(`{"body":`),
+			b:    aws.String(`{"body":`),
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := equalFilterPatterns(tt.a, tt.b); got != tt.want {
+				t.Errorf("equalFilterPatterns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_validateFilterCriteria(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		wantErr  string
+	}{
+		{name: "valid", patterns: []string{`{"body":{"a":["x"]}}`, `{"eventName":["INSERT"]}`}},
+		{name: "syntax error", patterns: []string{`{"body":{"a":["x"]}}`, `{"eventName":[`}, wantErr: "spec.filterCriteria.filters[1]"},
+		{name: "not an object", patterns: []string{`["INSERT"]`}, wantErr: "spec.filterCriteria.filters[0]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ko := &v1alpha1.EventSourceMapping{}
+			ko.Spec.FilterCriteria = &v1alpha1.FilterCriteria{}
+			for _, p := range tt.patterns {
+				ko.Spec.FilterCriteria.Filters = append(ko.Spec.FilterCriteria.Filters, &v1alpha1.Filter{Pattern: aws.String(p)})
+			}
+			err := validateFilterCriteria(ko)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateFilterCriteria() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateFilterCriteria() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_restoreFilterRules(t *testing.T) {
+	rules := []*v1alpha1.FilterRule{
+		{Path: aws.String("body.status"), Values: []*string{aws.String("active")}},
+	}
+	desired := &v1alpha1.EventSourceMapping{}
+	desired.Spec.FilterCriteria = &v1alpha1.FilterCriteria{
+		Filters: []*v1alpha1.Filter{
+			{Rules: rules},
+			{Rules: rules},
+			{Pattern: aws.String(`{"eventName":["INSERT"]}`)},
+		},
+	}
+	observed := &v1alpha1.EventSourceMapping{}
+	observed.Spec.FilterCriteria = &v1alpha1.FilterCriteria{
+		Filters: []*v1alpha1.Filter{
+			{Pattern: aws.String(`{"body": {"status": ["active"]}}`)},
+			{Pattern: aws.String(`{"body":{"status":["inactive"]}}`)},
+			{Pattern: aws.String(`{"eventName":["INSERT"]}`)},
+		},
+	}
+	restoreFilterRules(desired, observed)
+
+	filters := observed.Spec.FilterCriteria.Filters
+	if filters[0].Pattern != nil || len(filters[0].Rules) != 1 {
+		t.Errorf("expected the rules of the matching filter to be restored, got %+v", filters[0])
+	}
+	if filters[1].Rules != nil || *filters[1].Pattern != `{"body":{"status":["inactive"]}}` {
+		t.Errorf("expected the drifted filter to keep its pattern, got %+v", filters[1])
+	}
+	if filters[2].Rules != nil {
+		t.Errorf("expected the raw pattern filter to be left alone, got %+v", filters[2])
+	}
+}
+
+func Test_setFilterCriteriaErrorCondition(t *testing.T) {
+	r := &resource{ko: &v1alpha1.EventSourceMapping{}}
+	setFilterCriteriaErrorCondition(r)
+	if cond := ackcondition.FirstOfType(r, ConditionTypeFilterCriteriaError); cond == nil || cond.Status != corev1.ConditionFalse {
+		t.Fatalf("expected a False condition without a filter criteria error, got %v", cond)
+	}
+
+	r.ko.Status.FilterCriteriaError = &v1alpha1.FilterCriteriaError{
+		ErrorCode: aws.String("KMSAccessDeniedException"),
+		Message:   aws.String("access denied"),
+	}
+	setFilterCriteriaErrorCondition(r)
+	cond := ackcondition.FirstOfType(r, ConditionTypeFilterCriteriaError)
+	if cond == nil || cond.Status != corev1.ConditionTrue || *cond.Reason != "KMSAccessDeniedException" {
+		t.Fatalf("expected a True condition with the error code as reason, got %v", cond)
+	}
+
+	r.ko.Status.FilterCriteriaError = nil
+	setFilterCriteriaErrorCondition(r)
+	cond = ackcondition.FirstOfType(r, ConditionTypeFilterCriteriaError)
+	if cond == nil || cond.Status != corev1.ConditionFalse {
+		t.Fatalf("expected the condition to be lowered, got %v", cond)
+	}
+	if len(r.ko.Status.Conditions) != 1 {
+		t.Errorf("expected a single condition, got %d", len(r.ko.Status.Conditions))
+	}
+}
+
+func Test_setFilterCriteriaHiddenCondition(t *testing.T) {
+	keyARN := aws.String("arn:aws:kms:us-west-2:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab")
+	filters := &v1alpha1.FilterCriteria{
+		Filters: []*v1alpha1.Filter{{Pattern: aws.String(`{"body":{"status":["active"]}}`)}},
+	}
+	tests := []struct {
+		name     string
+		desired  v1alpha1.EventSourceMappingSpec
+		latest   v1alpha1.EventSourceMappingSpec
+		wantCond bool
+		want     corev1.ConditionStatus
+	}{
+		{
+			name:    "unencrypted",
+			desired: v1alpha1.EventSourceMappingSpec{FilterCriteria: filters},
+			latest:  v1alpha1.EventSourceMappingSpec{},
+		},
+		{
+			name:     "hidden",
+			desired:  v1alpha1.EventSourceMappingSpec{KMSKeyARN: keyARN, FilterCriteria: filters},
+			latest:   v1alpha1.EventSourceMappingSpec{KMSKeyARN: keyARN},
+			wantCond: true,
+			want:     corev1.ConditionTrue,
+		},
+		{
+			name:     "decrypted",
+			desired:  v1alpha1.EventSourceMappingSpec{KMSKeyARN: keyARN, FilterCriteria: filters},
+			latest:   v1alpha1.EventSourceMappingSpec{KMSKeyARN: keyARN, FilterCriteria: filters},
+			wantCond: true,
+			want:     corev1.ConditionFalse,
+		},
+		{
+			name:     "no filters",
+			desired:  v1alpha1.EventSourceMappingSpec{KMSKeyARN: keyARN},
+			latest:   v1alpha1.EventSourceMappingSpec{KMSKeyARN: keyARN},
+			wantCond: true,
+			want:     corev1.ConditionFalse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired := &resource{ko: &v1alpha1.EventSourceMapping{Spec: tt.desired}}
+			latest := &resource{ko: &v1alpha1.EventSourceMapping{Spec: tt.latest}}
+			setFilterCriteriaHiddenCondition(desired, latest)
+			cond := ackcondition.FirstOfType(latest, ConditionTypeFilterCriteriaHidden)
+			if !tt.wantCond {
+				if cond != nil {
+					t.Fatalf("expected no condition, got %v", cond)
+				}
+				return
+			}
+			if cond == nil || cond.Status != tt.want {
+				t.Fatalf("expected a %s condition, got %v", tt.want, cond)
+			}
+		})
+	}
+}
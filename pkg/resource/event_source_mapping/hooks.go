@@ -15,25 +15,73 @@ package event_source_mapping
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/lambda-controller/pkg/resource/reference"
 	acktags "github.com/aws-controllers-k8s/lambda-controller/pkg/resource/tags"
 )
 
+// +kubebuilder:rbac:groups=docdb.services.k8s.aws,resources=dbclusters,verbs=get;list
+// +kubebuilder:rbac:groups=docdb.services.k8s.aws,resources=dbclusters/status,verbs=get;list
+
+// dbClusterGVK is the kind of the clusters managed by the ACK controller for
+// Amazon DocumentDB.
+var dbClusterGVK = schema.GroupVersionKind{
+	Group:   "docdb.services.k8s.aws",
+	Version: "v1alpha1",
+	Kind:    "DBCluster",
+}
+
+var (
+	ErrProvisionedPollerConfigNotSupported = errors.New("ProvisionedPollerConfig is only supported for Amazon MSK and self-managed Apache Kafka event sources")
+)
+
 func customPreCompare(
 	delta *ackcompare.Delta,
 	a *resource,
 	b *resource,
 ) {
-	if ackcompare.HasNilDifference(a.ko.Spec.FilterCriteria, b.ko.Spec.FilterCriteria) {
-		delta.Add("Spec.FilterCriteria", a.ko.Spec.FilterCriteria, b.ko.Spec.FilterCriteria)
-	} else if a.ko.Spec.FilterCriteria != nil && b.ko.Spec.FilterCriteria != nil {
-		if !equalFilterSlices(a.ko.Spec.FilterCriteria.Filters, b.ko.Spec.FilterCriteria.Filters) {
-			delta.Add("Spec.FilterCriteria.Filters", a.ko.Spec.FilterCriteria, b.ko.Spec.FilterCriteria)
+	// Encrypted filter criteria that can't be read back are left alone,
+	// there is nothing to compare the desired ones with. The
+	// FilterCriteriaHidden condition reports them.
+	if !filterCriteriaHidden(b) {
+		if ackcompare.HasNilDifference(a.ko.Spec.FilterCriteria, b.ko.Spec.FilterCriteria) {
+			delta.Add("Spec.FilterCriteria", a.ko.Spec.FilterCriteria, b.ko.Spec.FilterCriteria)
+		} else if a.ko.Spec.FilterCriteria != nil && b.ko.Spec.FilterCriteria != nil {
+			if !equalFilterSlices(a.ko.Spec.FilterCriteria.Filters, b.ko.Spec.FilterCriteria.Filters) {
+				delta.Add("Spec.FilterCriteria.Filters", a.ko.Spec.FilterCriteria, b.ko.Spec.FilterCriteria)
+			}
 		}
 	}
+	if !equalSourceAccessConfigurations(a.ko.Spec.SourceAccessConfigurations, b.ko.Spec.SourceAccessConfigurations) {
+		delta.Add("Spec.SourceAccessConfigurations", a.ko.Spec.SourceAccessConfigurations, b.ko.Spec.SourceAccessConfigurations)
+	}
+	if !equalMetricsConfigs(a.ko.Spec.MetricsConfig, b.ko.Spec.MetricsConfig) {
+		delta.Add("Spec.MetricsConfig", a.ko.Spec.MetricsConfig, b.ko.Spec.MetricsConfig)
+	}
+}
+
+// filterCriteriaHidden returns true if the observed event source mapping
+// encrypts its filter criteria with a customer managed key and
+// GetEventSourceMapping didn't return them. Lambda only returns encrypted
+// filter criteria in plaintext when the caller is allowed to kms:Decrypt
+// with the key, otherwise the field is left empty.
+func filterCriteriaHidden(observed *resource) bool {
+	if observed.ko.Spec.KMSKeyARN == nil || *observed.ko.Spec.KMSKeyARN == "" {
+		return false
+	}
+	return observed.ko.Spec.FilterCriteria == nil ||
+		len(observed.ko.Spec.FilterCriteria.Filters) == 0
 }
 
 // equalFilterSlices returns whether two Filter arrays are
@@ -47,8 +95,15 @@ func equalFilterSlices(a, b []*v1alpha1.Filter) bool {
 	// submitted filters.
 	for x, aVal := range a {
 		bVal := b[x]
-		if ackcompare.HasNilDifference(aVal, bVal) ||
-			!equalStrings(aVal.Pattern, bVal.Pattern) {
+		if ackcompare.HasNilDifference(aVal, bVal) {
+			return false
+		}
+		if aVal == nil {
+			continue
+		}
+		aPattern, aErr := filterPattern(aVal)
+		bPattern, bErr := filterPattern(bVal)
+		if aErr != nil || bErr != nil || !equalFilterPatterns(aPattern, bPattern) {
 			return false
 		}
 	}
@@ -86,6 +141,100 @@ func filterCriteriasDeleted(
 	return false
 }
 
+// validateProvisionedPollerConfig returns an error if a provisioned poller
+// configuration is set for an event source other than Amazon MSK or
+// self-managed Apache Kafka.
+func validateProvisionedPollerConfig(ko *v1alpha1.EventSourceMapping) error {
+	if ko.Spec.ProvisionedPollerConfig == nil || isKafkaEventSource(ko) {
+		return nil
+	}
+	return ErrProvisionedPollerConfigNotSupported
+}
+
+// isKafkaEventSource returns true if the event source is an Amazon MSK cluster
+// or a self-managed Apache Kafka cluster.
+func isKafkaEventSource(ko *v1alpha1.EventSourceMapping) bool {
+	if ko.Spec.SelfManagedEventSource != nil {
+		_, ok := ko.Spec.SelfManagedEventSource.Endpoints[string(svcsdktypes.EndPointTypeKafkaBootstrapServers)]
+		return ok
+	}
+	if ko.Spec.EventSourceARN == nil {
+		return false
+	}
+	eventSourceARN, err := arn.Parse(*ko.Spec.EventSourceARN)
+	return err == nil && strings.EqualFold(eventSourceARN.Service, "kafka")
+}
+
+// equalSourceAccessConfigurations returns whether two SourceAccessConfiguration
+// arrays are equal or not. The URIRef fields are ignored, they're only set in
+// the desired resource.
+func equalSourceAccessConfigurations(a, b []*v1alpha1.SourceAccessConfiguration) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for x, aVal := range a {
+		bVal := b[x]
+		if ackcompare.HasNilDifference(aVal, bVal) {
+			return false
+		}
+		if aVal != nil && (!equalStrings(aVal.Type, bVal.Type) || !equalStrings(aVal.URI, bVal.URI)) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalMetricsConfigs returns whether two metrics configurations enable the
+// same set of metrics. A nil configuration and a configuration without any
+// metrics are equivalent, the Lambda API returns the latter once the metrics
+// have been turned off.
+func equalMetricsConfigs(a, b *v1alpha1.EventSourceMappingMetricsConfig) bool {
+	metrics := func(c *v1alpha1.EventSourceMappingMetricsConfig) map[string]bool {
+		set := map[string]bool{}
+		if c == nil {
+			return set
+		}
+		for _, m := range c.Metrics {
+			if m != nil {
+				set[*m] = true
+			}
+		}
+		return set
+	}
+	aMetrics, bMetrics := metrics(a), metrics(b)
+	if len(aMetrics) != len(bMetrics) {
+		return false
+	}
+	for m := range aMetrics {
+		if !bMetrics[m] {
+			return false
+		}
+	}
+	return true
+}
+
+// metricsConfigDeleted returns true if a user removed the
+// spec.metricsConfig field, or emptied its metrics list, while the
+// observed event source mapping still produces metrics.
+//
+// This function is used as a sdk_update_post_build_request hook, to
+// properly build an update call that will turn the ESM metrics off.
+func metricsConfigDeleted(
+	observed *resource,
+	desired *resource,
+	delta *ackcompare.Delta,
+) bool {
+	if !delta.DifferentAt("Spec.MetricsConfig") {
+		return false
+	}
+	if observed.ko.Spec.MetricsConfig == nil ||
+		len(observed.ko.Spec.MetricsConfig.Metrics) == 0 {
+		return false
+	}
+	return desired.ko.Spec.MetricsConfig == nil ||
+		len(desired.ko.Spec.MetricsConfig.Metrics) == 0
+}
+
 func equalStrings(a, b *string) bool {
 	if a == nil {
 		return b == nil || *b == ""
@@ -111,3 +260,38 @@ func (rm *resourceManager) syncTags(
 		desired.ko.Spec.Tags, latest.ko.Spec.Tags,
 	)
 }
+
+// resolveReferenceForDocumentDBCluster reads the DBCluster referenced from the
+// DocumentDBClusterRef field and sets the EventSourceARN from its ARN.
+func (rm *resourceManager) resolveReferenceForDocumentDBCluster(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *v1alpha1.EventSourceMapping,
+) (hasReferences bool, err error) {
+	if ko.Spec.DocumentDBClusterRef == nil || ko.Spec.DocumentDBClusterRef.From == nil {
+		return false, nil
+	}
+	hasReferences = true
+	if ko.Spec.EventSourceRef != nil || ko.Spec.EventSourceARN != nil {
+		return hasReferences, ackerr.ResourceReferenceAndIDNotSupportedFor("EventSourceARN", "DocumentDBClusterRef")
+	}
+	arr := ko.Spec.DocumentDBClusterRef.From
+	if arr.Name == nil || *arr.Name == "" {
+		return hasReferences, fmt.Errorf("provided resource reference is nil or empty: DocumentDBClusterRef")
+	}
+	value, err := reference.ResolveUnstructured(
+		ctx,
+		apiReader,
+		rm.cfg.EnableCrossNamespace,
+		&ko.Status.Conditions,
+		ko.ObjectMeta.GetNamespace(),
+		arr,
+		dbClusterGVK,
+		"status", "ackResourceMetadata", "arn",
+	)
+	if err != nil {
+		return hasReferences, err
+	}
+	ko.Spec.EventSourceARN = &value
+	return hasReferences, nil
+}
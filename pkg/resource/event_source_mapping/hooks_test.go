@@ -0,0 +1,186 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package event_source_mapping
+
+import (
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+)
+
+func Test_validateProvisionedPollerConfig(t *testing.T) {
+	pollers := &v1alpha1.ProvisionedPollerConfig{MinimumPollers: aws.Int64(1), MaximumPollers: aws.Int64(10)}
+	tests := []struct {
+		name    string
+		spec    v1alpha1.EventSourceMappingSpec
+		wantErr bool
+	}{
+		{
+			name: "no provisioned pollers",
+			spec: v1alpha1.EventSourceMappingSpec{
+				EventSourceARN: aws.String("arn:aws:sqs:us-west-2:123456789012:queue"),
+			},
+		},
+		{
+			name: "amazon msk",
+			spec: v1alpha1.EventSourceMappingSpec{
+				EventSourceARN:          aws.String("arn:aws:kafka:us-west-2:123456789012:cluster/orders/abcd-1234"),
+				ProvisionedPollerConfig: pollers,
+			},
+		},
+		{
+			name: "self-managed kafka",
+			spec: v1alpha1.EventSourceMappingSpec{
+				SelfManagedEventSource: &v1alpha1.SelfManagedEventSource{
+					Endpoints: map[string][]*string{
+						"KAFKA_BOOTSTRAP_SERVERS": {aws.String("broker-1:9092")},
+					},
+				},
+				ProvisionedPollerConfig: pollers,
+			},
+		},
+		{
+			name: "amazon sqs",
+			spec: v1alpha1.EventSourceMappingSpec{
+				EventSourceARN:          aws.String("arn:aws:sqs:us-west-2:123456789012:queue"),
+				ProvisionedPollerConfig: pollers,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unresolved event source",
+			spec: v1alpha1.EventSourceMappingSpec{
+				ProvisionedPollerConfig: pollers,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ko := &v1alpha1.EventSourceMapping{Spec: tt.spec}
+			if err := validateProvisionedPollerConfig(ko); (err != nil) != tt.wantErr {
+				t.Errorf("validateProvisionedPollerConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_equalMetricsConfigs(t *testing.T) {
+	eventCount := &v1alpha1.EventSourceMappingMetricsConfig{
+		Metrics: []*string{aws.String("EventCount")},
+	}
+	tests := []struct {
+		name string
+		a    *v1alpha1.EventSourceMappingMetricsConfig
+		b    *v1alpha1.EventSourceMappingMetricsConfig
+		want bool
+	}{
+		{name: "both nil", want: true},
+		{name: "nil and empty metrics", b: &v1alpha1.EventSourceMappingMetricsConfig{}, want: true},
+		{name: "nil and event count", b: eventCount, want: false},
+		{name: "same metrics", a: eventCount, b: eventCount, want: true},
+		{
+			name: "different order",
+			a: &v1alpha1.EventSourceMappingMetricsConfig{
+				Metrics: []*string{aws.String("EventCount"), aws.String("ErrorCount")},
+			},
+			b: &v1alpha1.EventSourceMappingMetricsConfig{
+				Metrics: []*string{aws.String("ErrorCount"), aws.String("EventCount")},
+			},
+			want: true,
+		},
+		{
+			name: "different metrics",
+			a:    eventCount,
+			b: &v1alpha1.EventSourceMappingMetricsConfig{
+				Metrics: []*string{aws.String("ErrorCount")},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := equalMetricsConfigs(tt.a, tt.b); got != tt.want {
+				t.Errorf("equalMetricsConfigs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_customPreCompare_encryptedFilterCriteria(t *testing.T) {
+	filters := &v1alpha1.FilterCriteria{
+		Filters: []*v1alpha1.Filter{{Pattern: aws.String(`{"body":{"status":["active"]}}`)}},
+	}
+	keyARN := aws.String("arn:aws:kms:us-west-2:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab")
+	tests := []struct {
+		name     string
+		observed v1alpha1.EventSourceMappingSpec
+		want     bool
+	}{
+		{
+			name:     "unencrypted filters missing",
+			observed: v1alpha1.EventSourceMappingSpec{},
+			want:     true,
+		},
+		{
+			name:     "encrypted filters hidden",
+			observed: v1alpha1.EventSourceMappingSpec{KMSKeyARN: keyARN},
+			want:     false,
+		},
+		{
+			name:     "encrypted filters decrypted",
+			observed: v1alpha1.EventSourceMappingSpec{KMSKeyARN: keyARN, FilterCriteria: filters},
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired := &resource{ko: &v1alpha1.EventSourceMapping{
+				Spec: v1alpha1.EventSourceMappingSpec{KMSKeyARN: keyARN, FilterCriteria: filters},
+			}}
+			if tt.observed.KMSKeyARN == nil {
+				desired.ko.Spec.KMSKeyARN = nil
+			}
+			observed := &resource{ko: &v1alpha1.EventSourceMapping{Spec: tt.observed}}
+			delta := ackcompare.NewDelta()
+			customPreCompare(delta, desired, observed)
+			if got := delta.DifferentAt("Spec.FilterCriteria"); got != tt.want {
+				t.Errorf("DifferentAt(Spec.FilterCriteria) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ClearResolvedReferences_documentDBCluster(t *testing.T) {
+	rm := &resourceManager{}
+	r := &resource{ko: &v1alpha1.EventSourceMapping{
+		Spec: v1alpha1.EventSourceMappingSpec{
+			EventSourceARN: aws.String("arn:aws:rds:us-west-2:123456789012:cluster:my-cluster"),
+			DocumentDBClusterRef: &ackv1alpha1.AWSResourceReferenceWrapper{
+				From: &ackv1alpha1.AWSResourceReference{Name: aws.String("my-cluster")},
+			},
+		},
+	}}
+	cleared := rm.ClearResolvedReferences(r).(*resource)
+	if cleared.ko.Spec.EventSourceARN != nil {
+		t.Errorf("expected the resolved EventSourceARN to be cleared, got %q", *cleared.ko.Spec.EventSourceARN)
+	}
+	if r.ko.Spec.EventSourceARN == nil {
+		t.Errorf("expected the supplied resource to be left alone")
+	}
+}
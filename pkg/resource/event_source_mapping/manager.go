@@ -271,6 +271,14 @@ func (rm *resourceManager) IsSynced(ctx context.Context, res acktypes.AWSResourc
 		panic("resource manager's IsSynced() method received resource with nil CR object")
 	}
 
+	if r.ko.Status.State == nil {
+		return false, nil
+	}
+	stateCandidates := []string{"Enabled", "Disabled"}
+	if !ackutil.InStrings(*r.ko.Status.State, stateCandidates) {
+		return false, nil
+	}
+
 	return true, nil
 }
 
@@ -24,6 +24,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kafkaapitypes "github.com/aws-controllers-k8s/kafka-controller/apis/v1alpha1"
+	kmsapitypes "github.com/aws-controllers-k8s/kms-controller/apis/v1alpha1"
 	mqapitypes "github.com/aws-controllers-k8s/mq-controller/apis/v1alpha1"
 	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
 	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
@@ -40,6 +41,9 @@ import (
 // +kubebuilder:rbac:groups=kafka.services.k8s.aws,resources=clusters,verbs=get;list
 // +kubebuilder:rbac:groups=kafka.services.k8s.aws,resources=clusters/status,verbs=get;list
 
+// +kubebuilder:rbac:groups=kms.services.k8s.aws,resources=keys,verbs=get;list
+// +kubebuilder:rbac:groups=kms.services.k8s.aws,resources=keys/status,verbs=get;list
+
 // +kubebuilder:rbac:groups=mq.services.k8s.aws,resources=brokers,verbs=get;list
 // +kubebuilder:rbac:groups=mq.services.k8s.aws,resources=brokers/status,verbs=get;list
 
@@ -64,14 +68,28 @@ func (rm *resourceManager) ClearResolvedReferences(res acktypes.AWSResource) ack
 		ko.Spec.EventSourceARN = nil
 	}
 
+	if ko.Spec.DocumentDBClusterRef != nil {
+		ko.Spec.EventSourceARN = nil
+	}
+
 	if ko.Spec.FunctionRef != nil {
 		ko.Spec.FunctionName = nil
 	}
 
+	if ko.Spec.KMSKeyRef != nil {
+		ko.Spec.KMSKeyARN = nil
+	}
+
 	if len(ko.Spec.QueueRefs) > 0 {
 		ko.Spec.Queues = nil
 	}
 
+	for f0idx, f0iter := range ko.Spec.SourceAccessConfigurations {
+		if f0iter.URIRef != nil {
+			ko.Spec.SourceAccessConfigurations[f0idx].URI = nil
+		}
+	}
+
 	return &resource{ko}
 }
 
@@ -109,12 +127,31 @@ func (rm *resourceManager) ResolveReferences(
 		resourceHasReferences = resourceHasReferences || fieldHasReferences
 	}
 
+	if fieldHasReferences, err := rm.resolveReferenceForKMSKeyARN(ctx, apiReader, ko); err != nil {
+		return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
+	} else {
+		resourceHasReferences = resourceHasReferences || fieldHasReferences
+	}
+
 	if fieldHasReferences, err := rm.resolveReferenceForQueues(ctx, apiReader, ko); err != nil {
 		return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
 	} else {
 		resourceHasReferences = resourceHasReferences || fieldHasReferences
 	}
 
+	if fieldHasReferences, err := rm.resolveReferenceForSourceAccessConfigurations_URI(ctx, apiReader, ko); err != nil {
+		return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
+	} else {
+		resourceHasReferences = resourceHasReferences || fieldHasReferences
+	}
+	if err == nil {
+		if fieldHasReferences, err := rm.resolveReferenceForDocumentDBCluster(ctx, apiReader, ko); err != nil {
+			return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
+		} else {
+			resourceHasReferences = resourceHasReferences || fieldHasReferences
+		}
+	}
+
 	return &resource{ko}, resourceHasReferences, err
 }
 
@@ -143,9 +180,19 @@ func validateReferenceFields(ko *svcapitypes.EventSourceMapping) error {
 		return ackerr.ResourceReferenceOrIDRequiredFor("FunctionName", "FunctionRef")
 	}
 
+	if ko.Spec.KMSKeyRef != nil && ko.Spec.KMSKeyARN != nil {
+		return ackerr.ResourceReferenceAndIDNotSupportedFor("KMSKeyARN", "KMSKeyRef")
+	}
+
 	if len(ko.Spec.QueueRefs) > 0 && len(ko.Spec.Queues) > 0 {
 		return ackerr.ResourceReferenceAndIDNotSupportedFor("Queues", "QueueRefs")
 	}
+
+	for _, f0iter := range ko.Spec.SourceAccessConfigurations {
+		if f0iter.URIRef != nil && f0iter.URI != nil {
+			return ackerr.ResourceReferenceAndIDNotSupportedFor("SourceAccessConfigurations.URI", "SourceAccessConfigurations.URIRef")
+		}
+	}
 	return nil
 }
 
@@ -428,6 +475,97 @@ func getReferencedResourceState_Function(
 	return nil
 }
 
+// resolveReferenceForKMSKeyARN reads the resource referenced
+// from KMSKeyRef field and sets the KMSKeyARN
+// from referenced resource. Returns a boolean indicating whether a reference
+// contains references, or an error
+func (rm *resourceManager) resolveReferenceForKMSKeyARN(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.EventSourceMapping,
+) (hasReferences bool, err error) {
+	if ko.Spec.KMSKeyRef != nil && ko.Spec.KMSKeyRef.From != nil {
+		hasReferences = true
+		arr := ko.Spec.KMSKeyRef.From
+		if arr.Name == nil || *arr.Name == "" {
+			return hasReferences, fmt.Errorf("provided resource reference is nil or empty: KMSKeyRef")
+		}
+		namespace, err := ackrt.ResolveCrossNamespaceReference(
+			ctx,
+			rm.cfg.EnableCrossNamespace,
+			&ko.Status.Conditions,
+			ackrt.CrossNamespaceRefKindResource,
+			ko.ObjectMeta.GetNamespace(),
+			arr.Namespace,
+			*arr.Name,
+		)
+		if err != nil {
+			return hasReferences, err
+		}
+		obj := &kmsapitypes.Key{}
+		if err := getReferencedResourceState_Key(ctx, apiReader, obj, *arr.Name, namespace); err != nil {
+			return hasReferences, err
+		}
+		ko.Spec.KMSKeyARN = (*string)(obj.Status.ACKResourceMetadata.ARN)
+	}
+
+	return hasReferences, nil
+}
+
+// getReferencedResourceState_Key looks up whether a referenced resource
+// exists and is in a ACK.ResourceSynced=True state. If the referenced resource does exist and is
+// in a Synced state, returns nil, otherwise returns `ackerr.ResourceReferenceTerminalFor` or
+// `ResourceReferenceNotSyncedFor` depending on if the resource is in a Terminal state.
+func getReferencedResourceState_Key(
+	ctx context.Context,
+	apiReader client.Reader,
+	obj *kmsapitypes.Key,
+	name string, // the Kubernetes name of the referenced resource
+	namespace string, // the Kubernetes namespace of the referenced resource
+) error {
+	namespacedName := types.NamespacedName{
+		Namespace: namespace,
+		Name:      name,
+	}
+	err := apiReader.Get(ctx, namespacedName, obj)
+	if err != nil {
+		return err
+	}
+	var refResourceTerminal bool
+	for _, cond := range obj.Status.Conditions {
+		if cond.Type == ackv1alpha1.ConditionTypeTerminal &&
+			cond.Status == corev1.ConditionTrue {
+			return ackerr.ResourceReferenceTerminalFor(
+				"Key",
+				namespace, name)
+		}
+	}
+	if refResourceTerminal {
+		return ackerr.ResourceReferenceTerminalFor(
+			"Key",
+			namespace, name)
+	}
+	var refResourceSynced bool
+	for _, cond := range obj.Status.Conditions {
+		if cond.Type == ackv1alpha1.ConditionTypeResourceSynced &&
+			cond.Status == corev1.ConditionTrue {
+			refResourceSynced = true
+		}
+	}
+	if !refResourceSynced {
+		return ackerr.ResourceReferenceNotSyncedFor(
+			"Key",
+			namespace, name)
+	}
+	if obj.Status.ACKResourceMetadata == nil || obj.Status.ACKResourceMetadata.ARN == nil {
+		return ackerr.ResourceReferenceMissingTargetFieldFor(
+			"Key",
+			namespace, name,
+			"Status.ACKResourceMetadata.ARN")
+	}
+	return nil
+}
+
 // resolveReferenceForQueues reads the resource referenced
 // from QueueRefs field and sets the Queues
 // from referenced resource. Returns a boolean indicating whether a reference
@@ -523,3 +661,42 @@ func getReferencedResourceState_Broker(
 	}
 	return nil
 }
+
+// resolveReferenceForSourceAccessConfigurations_URI reads the resource referenced
+// from SourceAccessConfigurations.URIRef field and sets the SourceAccessConfigurations.URI
+// from referenced resource. Returns a boolean indicating whether a reference
+// contains references, or an error
+func (rm *resourceManager) resolveReferenceForSourceAccessConfigurations_URI(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.EventSourceMapping,
+) (hasReferences bool, err error) {
+	for f0idx, f0iter := range ko.Spec.SourceAccessConfigurations {
+		if f0iter.URIRef != nil && f0iter.URIRef.From != nil {
+			hasReferences = true
+			arr := f0iter.URIRef.From
+			if arr.Name == nil || *arr.Name == "" {
+				return hasReferences, fmt.Errorf("provided resource reference is nil or empty: SourceAccessConfigurations.URIRef")
+			}
+			namespace, err := ackrt.ResolveCrossNamespaceReference(
+				ctx,
+				rm.cfg.EnableCrossNamespace,
+				&ko.Status.Conditions,
+				ackrt.CrossNamespaceRefKindResource,
+				ko.ObjectMeta.GetNamespace(),
+				arr.Namespace,
+				*arr.Name,
+			)
+			if err != nil {
+				return hasReferences, err
+			}
+			obj := &secretsmanagerapitypes.Secret{}
+			if err := getReferencedResourceState_Secret(ctx, apiReader, obj, *arr.Name, namespace); err != nil {
+				return hasReferences, err
+			}
+			ko.Spec.SourceAccessConfigurations[f0idx].URI = (*string)(obj.Status.ACKResourceMetadata.ARN)
+		}
+	}
+
+	return hasReferences, nil
+}
@@ -64,6 +64,12 @@ func (rm *resourceManager) sdkFind(
 	defer func() {
 		exit(err)
 	}()
+	if r.ko.Status.UUID == nil {
+		r, err = rm.findExistingMapping(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+	}
 	// If any required fields in the input shape are missing, AWS resource is
 	// not created yet. Return NotFound here to indicate to callers that the
 	// resource isn't yet created.
@@ -166,6 +172,21 @@ func (rm *resourceManager) sdkFind(
 	} else {
 		ko.Spec.DestinationConfig = nil
 	}
+	if resp.DocumentDBEventSourceConfig != nil {
+		f4 := &svcapitypes.DocumentDBEventSourceConfig{}
+		if resp.DocumentDBEventSourceConfig.CollectionName != nil {
+			f4.CollectionName = resp.DocumentDBEventSourceConfig.CollectionName
+		}
+		if resp.DocumentDBEventSourceConfig.DatabaseName != nil {
+			f4.DatabaseName = resp.DocumentDBEventSourceConfig.DatabaseName
+		}
+		if resp.DocumentDBEventSourceConfig.FullDocument != "" {
+			f4.FullDocument = aws.String(string(resp.DocumentDBEventSourceConfig.FullDocument))
+		}
+		ko.Spec.DocumentDBEventSourceConfig = f4
+	} else {
+		ko.Spec.DocumentDBEventSourceConfig = nil
+	}
 	if resp.EventSourceArn != nil {
 		ko.Spec.EventSourceARN = resp.EventSourceArn
 	} else {
@@ -195,6 +216,18 @@ func (rm *resourceManager) sdkFind(
 	} else {
 		ko.Spec.FilterCriteria = nil
 	}
+	if resp.FilterCriteriaError != nil {
+		f8 := &svcapitypes.FilterCriteriaError{}
+		if resp.FilterCriteriaError.ErrorCode != nil {
+			f8.ErrorCode = resp.FilterCriteriaError.ErrorCode
+		}
+		if resp.FilterCriteriaError.Message != nil {
+			f8.Message = resp.FilterCriteriaError.Message
+		}
+		ko.Status.FilterCriteriaError = f8
+	} else {
+		ko.Status.FilterCriteriaError = nil
+	}
 	if resp.FunctionArn != nil {
 		ko.Status.FunctionARN = resp.FunctionArn
 	} else {
@@ -211,6 +244,11 @@ func (rm *resourceManager) sdkFind(
 	} else {
 		ko.Spec.FunctionResponseTypes = nil
 	}
+	if resp.KMSKeyArn != nil {
+		ko.Spec.KMSKeyARN = resp.KMSKeyArn
+	} else {
+		ko.Spec.KMSKeyARN = nil
+	}
 	if resp.LastModified != nil {
 		ko.Status.LastModified = &metav1.Time{*resp.LastModified}
 	} else {
@@ -248,12 +286,44 @@ func (rm *resourceManager) sdkFind(
 	} else {
 		ko.Spec.MaximumRetryAttempts = nil
 	}
+	if resp.MetricsConfig != nil {
+		f18 := &svcapitypes.EventSourceMappingMetricsConfig{}
+		if resp.MetricsConfig.Metrics != nil {
+			f18f0 := []*string{}
+			for _, f18f0iter := range resp.MetricsConfig.Metrics {
+				var f18f0elem *string
+				f18f0elem = aws.String(string(f18f0iter))
+				f18f0 = append(f18f0, f18f0elem)
+			}
+			f18.Metrics = f18f0
+		}
+		ko.Spec.MetricsConfig = f18
+	} else {
+		ko.Spec.MetricsConfig = nil
+	}
 	if resp.ParallelizationFactor != nil {
 		parallelizationFactorCopy := int64(*resp.ParallelizationFactor)
 		ko.Spec.ParallelizationFactor = &parallelizationFactorCopy
 	} else {
 		ko.Spec.ParallelizationFactor = nil
 	}
+	if resp.ProvisionedPollerConfig != nil {
+		f20 := &svcapitypes.ProvisionedPollerConfig{}
+		if resp.ProvisionedPollerConfig.MaximumPollers != nil {
+			maximumPollersCopy := int64(*resp.ProvisionedPollerConfig.MaximumPollers)
+			f20.MaximumPollers = &maximumPollersCopy
+		}
+		if resp.ProvisionedPollerConfig.MinimumPollers != nil {
+			minimumPollersCopy := int64(*resp.ProvisionedPollerConfig.MinimumPollers)
+			f20.MinimumPollers = &minimumPollersCopy
+		}
+		if resp.ProvisionedPollerConfig.PollerGroupName != nil {
+			f20.PollerGroupName = resp.ProvisionedPollerConfig.PollerGroupName
+		}
+		ko.Spec.ProvisionedPollerConfig = f20
+	} else {
+		ko.Spec.ProvisionedPollerConfig = nil
+	}
 	if resp.Queues != nil {
 		ko.Spec.Queues = aws.StringSlice(resp.Queues)
 	} else {
@@ -380,10 +450,15 @@ func (rm *resourceManager) sdkFind(
 	}
 
 	rm.setStatusDefaults(ko)
+	setDegradedCondition(&resource{ko})
+	setFilterCriteriaErrorCondition(&resource{ko})
+	setFilterCriteriaHiddenCondition(r, &resource{ko})
+	restoreFilterRules(r.ko, ko)
 	ko.Spec.Tags, err = rm.getTags(ctx, string(*ko.Status.ACKResourceMetadata.ARN))
 	if err != nil {
 		return nil, err
 	}
+	delete(ko.Spec.Tags, ownerTagKey)
 
 	return &resource{ko}, nil
 }
@@ -424,10 +499,20 @@ func (rm *resourceManager) sdkCreate(
 	defer func() {
 		exit(err)
 	}()
+	if err = validateProvisionedPollerConfig(desired.ko); err != nil {
+		return nil, ackerr.NewTerminalError(err)
+	}
+	if err = validateFilterCriteria(desired.ko); err != nil {
+		return nil, ackerr.NewTerminalError(err)
+	}
 	input, err := rm.newCreateRequestPayload(ctx, desired)
 	if err != nil {
 		return nil, err
 	}
+	if err = setFilterPatterns(input.FilterCriteria, desired.ko); err != nil {
+		return nil, ackerr.NewTerminalError(err)
+	}
+	setOwnerTag(input, desired.ko)
 
 	var resp *svcsdk.CreateEventSourceMappingOutput
 	_ = resp
@@ -515,6 +600,21 @@ func (rm *resourceManager) sdkCreate(
 	} else {
 		ko.Spec.DestinationConfig = nil
 	}
+	if resp.DocumentDBEventSourceConfig != nil {
+		f4 := &svcapitypes.DocumentDBEventSourceConfig{}
+		if resp.DocumentDBEventSourceConfig.CollectionName != nil {
+			f4.CollectionName = resp.DocumentDBEventSourceConfig.CollectionName
+		}
+		if resp.DocumentDBEventSourceConfig.DatabaseName != nil {
+			f4.DatabaseName = resp.DocumentDBEventSourceConfig.DatabaseName
+		}
+		if resp.DocumentDBEventSourceConfig.FullDocument != "" {
+			f4.FullDocument = aws.String(string(resp.DocumentDBEventSourceConfig.FullDocument))
+		}
+		ko.Spec.DocumentDBEventSourceConfig = f4
+	} else {
+		ko.Spec.DocumentDBEventSourceConfig = nil
+	}
 	if resp.EventSourceArn != nil {
 		ko.Spec.EventSourceARN = resp.EventSourceArn
 	} else {
@@ -528,38 +628,55 @@ func (rm *resourceManager) sdkCreate(
 		ko.Status.ACKResourceMetadata.ARN = &arn
 	}
 	if resp.FilterCriteria != nil {
-		f6 := &svcapitypes.FilterCriteria{}
+		f7 := &svcapitypes.FilterCriteria{}
 		if resp.FilterCriteria.Filters != nil {
-			f6f0 := []*svcapitypes.Filter{}
-			for _, f6f0iter := range resp.FilterCriteria.Filters {
-				f6f0elem := &svcapitypes.Filter{}
-				if f6f0iter.Pattern != nil {
-					f6f0elem.Pattern = f6f0iter.Pattern
+			f7f0 := []*svcapitypes.Filter{}
+			for _, f7f0iter := range resp.FilterCriteria.Filters {
+				f7f0elem := &svcapitypes.Filter{}
+				if f7f0iter.Pattern != nil {
+					f7f0elem.Pattern = f7f0iter.Pattern
 				}
-				f6f0 = append(f6f0, f6f0elem)
+				f7f0 = append(f7f0, f7f0elem)
 			}
-			f6.Filters = f6f0
+			f7.Filters = f7f0
 		}
-		ko.Spec.FilterCriteria = f6
+		ko.Spec.FilterCriteria = f7
 	} else {
 		ko.Spec.FilterCriteria = nil
 	}
+	if resp.FilterCriteriaError != nil {
+		f8 := &svcapitypes.FilterCriteriaError{}
+		if resp.FilterCriteriaError.ErrorCode != nil {
+			f8.ErrorCode = resp.FilterCriteriaError.ErrorCode
+		}
+		if resp.FilterCriteriaError.Message != nil {
+			f8.Message = resp.FilterCriteriaError.Message
+		}
+		ko.Status.FilterCriteriaError = f8
+	} else {
+		ko.Status.FilterCriteriaError = nil
+	}
 	if resp.FunctionArn != nil {
 		ko.Status.FunctionARN = resp.FunctionArn
 	} else {
 		ko.Status.FunctionARN = nil
 	}
 	if resp.FunctionResponseTypes != nil {
-		f8 := []*string{}
-		for _, f8iter := range resp.FunctionResponseTypes {
-			var f8elem *string
-			f8elem = aws.String(string(f8iter))
-			f8 = append(f8, f8elem)
+		f10 := []*string{}
+		for _, f10iter := range resp.FunctionResponseTypes {
+			var f10elem *string
+			f10elem = aws.String(string(f10iter))
+			f10 = append(f10, f10elem)
 		}
-		ko.Spec.FunctionResponseTypes = f8
+		ko.Spec.FunctionResponseTypes = f10
 	} else {
 		ko.Spec.FunctionResponseTypes = nil
 	}
+	if resp.KMSKeyArn != nil {
+		ko.Spec.KMSKeyARN = resp.KMSKeyArn
+	} else {
+		ko.Spec.KMSKeyARN = nil
+	}
 	if resp.LastModified != nil {
 		ko.Status.LastModified = &metav1.Time{*resp.LastModified}
 	} else {
@@ -571,11 +688,11 @@ func (rm *resourceManager) sdkCreate(
 		ko.Status.LastProcessingResult = nil
 	}
 	if resp.LoggingConfig != nil {
-		f11 := &svcapitypes.EventSourceMappingLoggingConfig{}
+		f14 := &svcapitypes.EventSourceMappingLoggingConfig{}
 		if resp.LoggingConfig.SystemLogLevel != "" {
-			f11.SystemLogLevel = aws.String(string(resp.LoggingConfig.SystemLogLevel))
+			f14.SystemLogLevel = aws.String(string(resp.LoggingConfig.SystemLogLevel))
 		}
-		ko.Spec.LoggingConfig = f11
+		ko.Spec.LoggingConfig = f14
 	} else {
 		ko.Spec.LoggingConfig = nil
 	}
@@ -597,97 +714,129 @@ func (rm *resourceManager) sdkCreate(
 	} else {
 		ko.Spec.MaximumRetryAttempts = nil
 	}
+	if resp.MetricsConfig != nil {
+		f18 := &svcapitypes.EventSourceMappingMetricsConfig{}
+		if resp.MetricsConfig.Metrics != nil {
+			f18f0 := []*string{}
+			for _, f18f0iter := range resp.MetricsConfig.Metrics {
+				var f18f0elem *string
+				f18f0elem = aws.String(string(f18f0iter))
+				f18f0 = append(f18f0, f18f0elem)
+			}
+			f18.Metrics = f18f0
+		}
+		ko.Spec.MetricsConfig = f18
+	} else {
+		ko.Spec.MetricsConfig = nil
+	}
 	if resp.ParallelizationFactor != nil {
 		parallelizationFactorCopy := int64(*resp.ParallelizationFactor)
 		ko.Spec.ParallelizationFactor = &parallelizationFactorCopy
 	} else {
 		ko.Spec.ParallelizationFactor = nil
 	}
+	if resp.ProvisionedPollerConfig != nil {
+		f20 := &svcapitypes.ProvisionedPollerConfig{}
+		if resp.ProvisionedPollerConfig.MaximumPollers != nil {
+			maximumPollersCopy := int64(*resp.ProvisionedPollerConfig.MaximumPollers)
+			f20.MaximumPollers = &maximumPollersCopy
+		}
+		if resp.ProvisionedPollerConfig.MinimumPollers != nil {
+			minimumPollersCopy := int64(*resp.ProvisionedPollerConfig.MinimumPollers)
+			f20.MinimumPollers = &minimumPollersCopy
+		}
+		if resp.ProvisionedPollerConfig.PollerGroupName != nil {
+			f20.PollerGroupName = resp.ProvisionedPollerConfig.PollerGroupName
+		}
+		ko.Spec.ProvisionedPollerConfig = f20
+	} else {
+		ko.Spec.ProvisionedPollerConfig = nil
+	}
 	if resp.Queues != nil {
 		ko.Spec.Queues = aws.StringSlice(resp.Queues)
 	} else {
 		ko.Spec.Queues = nil
 	}
 	if resp.ScalingConfig != nil {
-		f17 := &svcapitypes.ScalingConfig{}
+		f22 := &svcapitypes.ScalingConfig{}
 		if resp.ScalingConfig.MaximumConcurrency != nil {
 			maximumConcurrencyCopy := int64(*resp.ScalingConfig.MaximumConcurrency)
-			f17.MaximumConcurrency = &maximumConcurrencyCopy
+			f22.MaximumConcurrency = &maximumConcurrencyCopy
 		}
-		ko.Spec.ScalingConfig = f17
+		ko.Spec.ScalingConfig = f22
 	} else {
 		ko.Spec.ScalingConfig = nil
 	}
 	if resp.SelfManagedEventSource != nil {
-		f18 := &svcapitypes.SelfManagedEventSource{}
+		f23 := &svcapitypes.SelfManagedEventSource{}
 		if resp.SelfManagedEventSource.Endpoints != nil {
-			f18f0 := map[string][]*string{}
-			for f18f0key, f18f0valiter := range resp.SelfManagedEventSource.Endpoints {
-				f18f0[f18f0key] = aws.StringSlice(f18f0valiter)
+			f23f0 := map[string][]*string{}
+			for f23f0key, f23f0valiter := range resp.SelfManagedEventSource.Endpoints {
+				f23f0[f23f0key] = aws.StringSlice(f23f0valiter)
 			}
-			f18.Endpoints = f18f0
+			f23.Endpoints = f23f0
 		}
-		ko.Spec.SelfManagedEventSource = f18
+		ko.Spec.SelfManagedEventSource = f23
 	} else {
 		ko.Spec.SelfManagedEventSource = nil
 	}
 	if resp.SelfManagedKafkaEventSourceConfig != nil {
-		f19 := &svcapitypes.SelfManagedKafkaEventSourceConfig{}
+		f24 := &svcapitypes.SelfManagedKafkaEventSourceConfig{}
 		if resp.SelfManagedKafkaEventSourceConfig.ConsumerGroupId != nil {
-			f19.ConsumerGroupID = resp.SelfManagedKafkaEventSourceConfig.ConsumerGroupId
+			f24.ConsumerGroupID = resp.SelfManagedKafkaEventSourceConfig.ConsumerGroupId
 		}
 		if resp.SelfManagedKafkaEventSourceConfig.SchemaRegistryConfig != nil {
-			f19f1 := &svcapitypes.KafkaSchemaRegistryConfig{}
+			f24f1 := &svcapitypes.KafkaSchemaRegistryConfig{}
 			if resp.SelfManagedKafkaEventSourceConfig.SchemaRegistryConfig.AccessConfigs != nil {
-				f19f1f0 := []*svcapitypes.KafkaSchemaRegistryAccessConfig{}
-				for _, f19f1f0iter := range resp.SelfManagedKafkaEventSourceConfig.SchemaRegistryConfig.AccessConfigs {
-					f19f1f0elem := &svcapitypes.KafkaSchemaRegistryAccessConfig{}
-					if f19f1f0iter.Type != "" {
-						f19f1f0elem.Type = aws.String(string(f19f1f0iter.Type))
+				f24f1f0 := []*svcapitypes.KafkaSchemaRegistryAccessConfig{}
+				for _, f24f1f0iter := range resp.SelfManagedKafkaEventSourceConfig.SchemaRegistryConfig.AccessConfigs {
+					f24f1f0elem := &svcapitypes.KafkaSchemaRegistryAccessConfig{}
+					if f24f1f0iter.Type != "" {
+						f24f1f0elem.Type = aws.String(string(f24f1f0iter.Type))
 					}
-					if f19f1f0iter.URI != nil {
-						f19f1f0elem.URI = f19f1f0iter.URI
+					if f24f1f0iter.URI != nil {
+						f24f1f0elem.URI = f24f1f0iter.URI
 					}
-					f19f1f0 = append(f19f1f0, f19f1f0elem)
+					f24f1f0 = append(f24f1f0, f24f1f0elem)
 				}
-				f19f1.AccessConfigs = f19f1f0
+				f24f1.AccessConfigs = f24f1f0
 			}
 			if resp.SelfManagedKafkaEventSourceConfig.SchemaRegistryConfig.EventRecordFormat != "" {
-				f19f1.EventRecordFormat = aws.String(string(resp.SelfManagedKafkaEventSourceConfig.SchemaRegistryConfig.EventRecordFormat))
+				f24f1.EventRecordFormat = aws.String(string(resp.SelfManagedKafkaEventSourceConfig.SchemaRegistryConfig.EventRecordFormat))
 			}
 			if resp.SelfManagedKafkaEventSourceConfig.SchemaRegistryConfig.SchemaRegistryURI != nil {
-				f19f1.SchemaRegistryURI = resp.SelfManagedKafkaEventSourceConfig.SchemaRegistryConfig.SchemaRegistryURI
+				f24f1.SchemaRegistryURI = resp.SelfManagedKafkaEventSourceConfig.SchemaRegistryConfig.SchemaRegistryURI
 			}
 			if resp.SelfManagedKafkaEventSourceConfig.SchemaRegistryConfig.SchemaValidationConfigs != nil {
-				f19f1f3 := []*svcapitypes.KafkaSchemaValidationConfig{}
-				for _, f19f1f3iter := range resp.SelfManagedKafkaEventSourceConfig.SchemaRegistryConfig.SchemaValidationConfigs {
-					f19f1f3elem := &svcapitypes.KafkaSchemaValidationConfig{}
-					if f19f1f3iter.Attribute != "" {
-						f19f1f3elem.Attribute = aws.String(string(f19f1f3iter.Attribute))
+				f24f1f3 := []*svcapitypes.KafkaSchemaValidationConfig{}
+				for _, f24f1f3iter := range resp.SelfManagedKafkaEventSourceConfig.SchemaRegistryConfig.SchemaValidationConfigs {
+					f24f1f3elem := &svcapitypes.KafkaSchemaValidationConfig{}
+					if f24f1f3iter.Attribute != "" {
+						f24f1f3elem.Attribute = aws.String(string(f24f1f3iter.Attribute))
 					}
-					f19f1f3 = append(f19f1f3, f19f1f3elem)
+					f24f1f3 = append(f24f1f3, f24f1f3elem)
 				}
-				f19f1.SchemaValidationConfigs = f19f1f3
+				f24f1.SchemaValidationConfigs = f24f1f3
 			}
-			f19.SchemaRegistryConfig = f19f1
+			f24.SchemaRegistryConfig = f24f1
 		}
-		ko.Spec.SelfManagedKafkaEventSourceConfig = f19
+		ko.Spec.SelfManagedKafkaEventSourceConfig = f24
 	} else {
 		ko.Spec.SelfManagedKafkaEventSourceConfig = nil
 	}
 	if resp.SourceAccessConfigurations != nil {
-		f20 := []*svcapitypes.SourceAccessConfiguration{}
-		for _, f20iter := range resp.SourceAccessConfigurations {
-			f20elem := &svcapitypes.SourceAccessConfiguration{}
-			if f20iter.Type != "" {
-				f20elem.Type = aws.String(string(f20iter.Type))
+		f25 := []*svcapitypes.SourceAccessConfiguration{}
+		for _, f25iter := range resp.SourceAccessConfigurations {
+			f25elem := &svcapitypes.SourceAccessConfiguration{}
+			if f25iter.Type != "" {
+				f25elem.Type = aws.String(string(f25iter.Type))
 			}
-			if f20iter.URI != nil {
-				f20elem.URI = f20iter.URI
+			if f25iter.URI != nil {
+				f25elem.URI = f25iter.URI
 			}
-			f20 = append(f20, f20elem)
+			f25 = append(f25, f25elem)
 		}
-		ko.Spec.SourceAccessConfigurations = f20
+		ko.Spec.SourceAccessConfigurations = f25
 	} else {
 		ko.Spec.SourceAccessConfigurations = nil
 	}
@@ -729,6 +878,8 @@ func (rm *resourceManager) sdkCreate(
 	}
 
 	rm.setStatusDefaults(ko)
+	setFilterCriteriaErrorCondition(&resource{ko})
+	restoreFilterRules(desired.ko, ko)
 	return &resource{ko}, nil
 }
 
@@ -811,6 +962,19 @@ func (rm *resourceManager) newCreateRequestPayload(
 		}
 		res.DestinationConfig = f3
 	}
+	if r.ko.Spec.DocumentDBEventSourceConfig != nil {
+		f4 := &svcsdktypes.DocumentDBEventSourceConfig{}
+		if r.ko.Spec.DocumentDBEventSourceConfig.CollectionName != nil {
+			f4.CollectionName = r.ko.Spec.DocumentDBEventSourceConfig.CollectionName
+		}
+		if r.ko.Spec.DocumentDBEventSourceConfig.DatabaseName != nil {
+			f4.DatabaseName = r.ko.Spec.DocumentDBEventSourceConfig.DatabaseName
+		}
+		if r.ko.Spec.DocumentDBEventSourceConfig.FullDocument != nil {
+			f4.FullDocument = svcsdktypes.FullDocument(*r.ko.Spec.DocumentDBEventSourceConfig.FullDocument)
+		}
+		res.DocumentDBEventSourceConfig = f4
+	}
 	if r.ko.Spec.Enabled != nil {
 		res.Enabled = r.ko.Spec.Enabled
 	}
@@ -818,38 +982,41 @@ func (rm *resourceManager) newCreateRequestPayload(
 		res.EventSourceArn = r.ko.Spec.EventSourceARN
 	}
 	if r.ko.Spec.FilterCriteria != nil {
-		f6 := &svcsdktypes.FilterCriteria{}
+		f7 := &svcsdktypes.FilterCriteria{}
 		if r.ko.Spec.FilterCriteria.Filters != nil {
-			f6f0 := []svcsdktypes.Filter{}
-			for _, f6f0iter := range r.ko.Spec.FilterCriteria.Filters {
-				f6f0elem := &svcsdktypes.Filter{}
-				if f6f0iter.Pattern != nil {
-					f6f0elem.Pattern = f6f0iter.Pattern
+			f7f0 := []svcsdktypes.Filter{}
+			for _, f7f0iter := range r.ko.Spec.FilterCriteria.Filters {
+				f7f0elem := &svcsdktypes.Filter{}
+				if f7f0iter.Pattern != nil {
+					f7f0elem.Pattern = f7f0iter.Pattern
 				}
-				f6f0 = append(f6f0, *f6f0elem)
+				f7f0 = append(f7f0, *f7f0elem)
 			}
-			f6.Filters = f6f0
+			f7.Filters = f7f0
 		}
-		res.FilterCriteria = f6
+		res.FilterCriteria = f7
 	}
 	if r.ko.Spec.FunctionName != nil {
 		res.FunctionName = r.ko.Spec.FunctionName
 	}
 	if r.ko.Spec.FunctionResponseTypes != nil {
-		f8 := []svcsdktypes.FunctionResponseType{}
-		for _, f8iter := range r.ko.Spec.FunctionResponseTypes {
-			var f8elem string
-			f8elem = string(*f8iter)
-			f8 = append(f8, svcsdktypes.FunctionResponseType(f8elem))
+		f9 := []svcsdktypes.FunctionResponseType{}
+		for _, f9iter := range r.ko.Spec.FunctionResponseTypes {
+			var f9elem string
+			f9elem = string(*f9iter)
+			f9 = append(f9, svcsdktypes.FunctionResponseType(f9elem))
 		}
-		res.FunctionResponseTypes = f8
+		res.FunctionResponseTypes = f9
+	}
+	if r.ko.Spec.KMSKeyARN != nil {
+		res.KMSKeyArn = r.ko.Spec.KMSKeyARN
 	}
 	if r.ko.Spec.LoggingConfig != nil {
-		f9 := &svcsdktypes.EventSourceMappingLoggingConfig{}
+		f11 := &svcsdktypes.EventSourceMappingLoggingConfig{}
 		if r.ko.Spec.LoggingConfig.SystemLogLevel != nil {
-			f9.SystemLogLevel = svcsdktypes.EventSourceMappingSystemLogLevel(*r.ko.Spec.LoggingConfig.SystemLogLevel)
+			f11.SystemLogLevel = svcsdktypes.EventSourceMappingSystemLogLevel(*r.ko.Spec.LoggingConfig.SystemLogLevel)
 		}
-		res.LoggingConfig = f9
+		res.LoggingConfig = f11
 	}
 	if r.ko.Spec.MaximumBatchingWindowInSeconds != nil {
 		maximumBatchingWindowInSecondsCopy0 := *r.ko.Spec.MaximumBatchingWindowInSeconds
@@ -875,6 +1042,19 @@ func (rm *resourceManager) newCreateRequestPayload(
 		maximumRetryAttemptsCopy := int32(maximumRetryAttemptsCopy0)
 		res.MaximumRetryAttempts = &maximumRetryAttemptsCopy
 	}
+	if r.ko.Spec.MetricsConfig != nil {
+		f15 := &svcsdktypes.EventSourceMappingMetricsConfig{}
+		if r.ko.Spec.MetricsConfig.Metrics != nil {
+			f15f0 := []svcsdktypes.EventSourceMappingMetric{}
+			for _, f15f0iter := range r.ko.Spec.MetricsConfig.Metrics {
+				var f15f0elem string
+				f15f0elem = string(*f15f0iter)
+				f15f0 = append(f15f0, svcsdktypes.EventSourceMappingMetric(f15f0elem))
+			}
+			f15.Metrics = f15f0
+		}
+		res.MetricsConfig = f15
+	}
 	if r.ko.Spec.ParallelizationFactor != nil {
 		parallelizationFactorCopy0 := *r.ko.Spec.ParallelizationFactor
 		if parallelizationFactorCopy0 > math.MaxInt32 || parallelizationFactorCopy0 < math.MinInt32 {
@@ -883,87 +1063,110 @@ func (rm *resourceManager) newCreateRequestPayload(
 		parallelizationFactorCopy := int32(parallelizationFactorCopy0)
 		res.ParallelizationFactor = &parallelizationFactorCopy
 	}
+	if r.ko.Spec.ProvisionedPollerConfig != nil {
+		f17 := &svcsdktypes.ProvisionedPollerConfig{}
+		if r.ko.Spec.ProvisionedPollerConfig.MaximumPollers != nil {
+			maximumPollersCopy0 := *r.ko.Spec.ProvisionedPollerConfig.MaximumPollers
+			if maximumPollersCopy0 > math.MaxInt32 || maximumPollersCopy0 < math.MinInt32 {
+				return nil, fmt.Errorf("error: field MaximumPollers is of type int32")
+			}
+			maximumPollersCopy := int32(maximumPollersCopy0)
+			f17.MaximumPollers = &maximumPollersCopy
+		}
+		if r.ko.Spec.ProvisionedPollerConfig.MinimumPollers != nil {
+			minimumPollersCopy0 := *r.ko.Spec.ProvisionedPollerConfig.MinimumPollers
+			if minimumPollersCopy0 > math.MaxInt32 || minimumPollersCopy0 < math.MinInt32 {
+				return nil, fmt.Errorf("error: field MinimumPollers is of type int32")
+			}
+			minimumPollersCopy := int32(minimumPollersCopy0)
+			f17.MinimumPollers = &minimumPollersCopy
+		}
+		if r.ko.Spec.ProvisionedPollerConfig.PollerGroupName != nil {
+			f17.PollerGroupName = r.ko.Spec.ProvisionedPollerConfig.PollerGroupName
+		}
+		res.ProvisionedPollerConfig = f17
+	}
 	if r.ko.Spec.Queues != nil {
 		res.Queues = aws.ToStringSlice(r.ko.Spec.Queues)
 	}
 	if r.ko.Spec.ScalingConfig != nil {
-		f15 := &svcsdktypes.ScalingConfig{}
+		f19 := &svcsdktypes.ScalingConfig{}
 		if r.ko.Spec.ScalingConfig.MaximumConcurrency != nil {
 			maximumConcurrencyCopy0 := *r.ko.Spec.ScalingConfig.MaximumConcurrency
 			if maximumConcurrencyCopy0 > math.MaxInt32 || maximumConcurrencyCopy0 < math.MinInt32 {
 				return nil, fmt.Errorf("error: field MaximumConcurrency is of type int32")
 			}
 			maximumConcurrencyCopy := int32(maximumConcurrencyCopy0)
-			f15.MaximumConcurrency = &maximumConcurrencyCopy
+			f19.MaximumConcurrency = &maximumConcurrencyCopy
 		}
-		res.ScalingConfig = f15
+		res.ScalingConfig = f19
 	}
 	if r.ko.Spec.SelfManagedEventSource != nil {
-		f16 := &svcsdktypes.SelfManagedEventSource{}
+		f20 := &svcsdktypes.SelfManagedEventSource{}
 		if r.ko.Spec.SelfManagedEventSource.Endpoints != nil {
-			f16f0 := map[string][]string{}
-			for f16f0key, f16f0valiter := range r.ko.Spec.SelfManagedEventSource.Endpoints {
-				f16f0[f16f0key] = aws.ToStringSlice(f16f0valiter)
+			f20f0 := map[string][]string{}
+			for f20f0key, f20f0valiter := range r.ko.Spec.SelfManagedEventSource.Endpoints {
+				f20f0[f20f0key] = aws.ToStringSlice(f20f0valiter)
 			}
-			f16.Endpoints = f16f0
+			f20.Endpoints = f20f0
 		}
-		res.SelfManagedEventSource = f16
+		res.SelfManagedEventSource = f20
 	}
 	if r.ko.Spec.SelfManagedKafkaEventSourceConfig != nil {
-		f17 := &svcsdktypes.SelfManagedKafkaEventSourceConfig{}
+		f21 := &svcsdktypes.SelfManagedKafkaEventSourceConfig{}
 		if r.ko.Spec.SelfManagedKafkaEventSourceConfig.ConsumerGroupID != nil {
-			f17.ConsumerGroupId = r.ko.Spec.SelfManagedKafkaEventSourceConfig.ConsumerGroupID
+			f21.ConsumerGroupId = r.ko.Spec.SelfManagedKafkaEventSourceConfig.ConsumerGroupID
 		}
 		if r.ko.Spec.SelfManagedKafkaEventSourceConfig.SchemaRegistryConfig != nil {
-			f17f1 := &svcsdktypes.KafkaSchemaRegistryConfig{}
+			f21f1 := &svcsdktypes.KafkaSchemaRegistryConfig{}
 			if r.ko.Spec.SelfManagedKafkaEventSourceConfig.SchemaRegistryConfig.AccessConfigs != nil {
-				f17f1f0 := []svcsdktypes.KafkaSchemaRegistryAccessConfig{}
-				for _, f17f1f0iter := range r.ko.Spec.SelfManagedKafkaEventSourceConfig.SchemaRegistryConfig.AccessConfigs {
-					f17f1f0elem := &svcsdktypes.KafkaSchemaRegistryAccessConfig{}
-					if f17f1f0iter.Type != nil {
-						f17f1f0elem.Type = svcsdktypes.KafkaSchemaRegistryAuthType(*f17f1f0iter.Type)
+				f21f1f0 := []svcsdktypes.KafkaSchemaRegistryAccessConfig{}
+				for _, f21f1f0iter := range r.ko.Spec.SelfManagedKafkaEventSourceConfig.SchemaRegistryConfig.AccessConfigs {
+					f21f1f0elem := &svcsdktypes.KafkaSchemaRegistryAccessConfig{}
+					if f21f1f0iter.Type != nil {
+						f21f1f0elem.Type = svcsdktypes.KafkaSchemaRegistryAuthType(*f21f1f0iter.Type)
 					}
-					if f17f1f0iter.URI != nil {
-						f17f1f0elem.URI = f17f1f0iter.URI
+					if f21f1f0iter.URI != nil {
+						f21f1f0elem.URI = f21f1f0iter.URI
 					}
-					f17f1f0 = append(f17f1f0, *f17f1f0elem)
+					f21f1f0 = append(f21f1f0, *f21f1f0elem)
 				}
-				f17f1.AccessConfigs = f17f1f0
+				f21f1.AccessConfigs = f21f1f0
 			}
 			if r.ko.Spec.SelfManagedKafkaEventSourceConfig.SchemaRegistryConfig.EventRecordFormat != nil {
-				f17f1.EventRecordFormat = svcsdktypes.SchemaRegistryEventRecordFormat(*r.ko.Spec.SelfManagedKafkaEventSourceConfig.SchemaRegistryConfig.EventRecordFormat)
+				f21f1.EventRecordFormat = svcsdktypes.SchemaRegistryEventRecordFormat(*r.ko.Spec.SelfManagedKafkaEventSourceConfig.SchemaRegistryConfig.EventRecordFormat)
 			}
 			if r.ko.Spec.SelfManagedKafkaEventSourceConfig.SchemaRegistryConfig.SchemaRegistryURI != nil {
-				f17f1.SchemaRegistryURI = r.ko.Spec.SelfManagedKafkaEventSourceConfig.SchemaRegistryConfig.SchemaRegistryURI
+				f21f1.SchemaRegistryURI = r.ko.Spec.SelfManagedKafkaEventSourceConfig.SchemaRegistryConfig.SchemaRegistryURI
 			}
 			if r.ko.Spec.SelfManagedKafkaEventSourceConfig.SchemaRegistryConfig.SchemaValidationConfigs != nil {
-				f17f1f3 := []svcsdktypes.KafkaSchemaValidationConfig{}
-				for _, f17f1f3iter := range r.ko.Spec.SelfManagedKafkaEventSourceConfig.SchemaRegistryConfig.SchemaValidationConfigs {
-					f17f1f3elem := &svcsdktypes.KafkaSchemaValidationConfig{}
-					if f17f1f3iter.Attribute != nil {
-						f17f1f3elem.Attribute = svcsdktypes.KafkaSchemaValidationAttribute(*f17f1f3iter.Attribute)
+				f21f1f3 := []svcsdktypes.KafkaSchemaValidationConfig{}
+				for _, f21f1f3iter := range r.ko.Spec.SelfManagedKafkaEventSourceConfig.SchemaRegistryConfig.SchemaValidationConfigs {
+					f21f1f3elem := &svcsdktypes.KafkaSchemaValidationConfig{}
+					if f21f1f3iter.Attribute != nil {
+						f21f1f3elem.Attribute = svcsdktypes.KafkaSchemaValidationAttribute(*f21f1f3iter.Attribute)
 					}
-					f17f1f3 = append(f17f1f3, *f17f1f3elem)
+					f21f1f3 = append(f21f1f3, *f21f1f3elem)
 				}
-				f17f1.SchemaValidationConfigs = f17f1f3
+				f21f1.SchemaValidationConfigs = f21f1f3
 			}
-			f17.SchemaRegistryConfig = f17f1
+			f21.SchemaRegistryConfig = f21f1
 		}
-		res.SelfManagedKafkaEventSourceConfig = f17
+		res.SelfManagedKafkaEventSourceConfig = f21
 	}
 	if r.ko.Spec.SourceAccessConfigurations != nil {
-		f18 := []svcsdktypes.SourceAccessConfiguration{}
-		for _, f18iter := range r.ko.Spec.SourceAccessConfigurations {
-			f18elem := &svcsdktypes.SourceAccessConfiguration{}
-			if f18iter.Type != nil {
-				f18elem.Type = svcsdktypes.SourceAccessType(*f18iter.Type)
+		f22 := []svcsdktypes.SourceAccessConfiguration{}
+		for _, f22iter := range r.ko.Spec.SourceAccessConfigurations {
+			f22elem := &svcsdktypes.SourceAccessConfiguration{}
+			if f22iter.Type != nil {
+				f22elem.Type = svcsdktypes.SourceAccessType(*f22iter.Type)
 			}
-			if f18iter.URI != nil {
-				f18elem.URI = f18iter.URI
+			if f22iter.URI != nil {
+				f22elem.URI = f22iter.URI
 			}
-			f18 = append(f18, *f18elem)
+			f22 = append(f22, *f22elem)
 		}
-		res.SourceAccessConfigurations = f18
+		res.SourceAccessConfigurations = f22
 	}
 	if r.ko.Spec.StartingPosition != nil {
 		res.StartingPosition = svcsdktypes.EventSourcePosition(*r.ko.Spec.StartingPosition)
@@ -1002,6 +1205,11 @@ func (rm *resourceManager) sdkUpdate(
 	defer func() {
 		exit(err)
 	}()
+	if isTransitioning(latest) {
+		updated := rm.concreteResource(desired.DeepCopy())
+		updated.SetStatus(latest)
+		return updated, requeueWaitWhileTransitioning
+	}
 	if delta.DifferentAt("Spec.Tags") {
 		err := rm.syncTags(ctx, desired, latest)
 		if err != nil {
@@ -1011,12 +1219,21 @@ func (rm *resourceManager) sdkUpdate(
 	if !delta.DifferentExcept("Spec.Tags") {
 		return desired, nil
 	}
+	if err = validateProvisionedPollerConfig(desired.ko); err != nil {
+		return nil, ackerr.NewTerminalError(err)
+	}
+	if err = validateFilterCriteria(desired.ko); err != nil {
+		return nil, ackerr.NewTerminalError(err)
+	}
 
 	input, err := rm.newUpdateRequestPayload(ctx, desired, delta)
 	if err != nil {
 		return nil, err
 	}
 
+	if err = setFilterPatterns(input.FilterCriteria, desired.ko); err != nil {
+		return nil, ackerr.NewTerminalError(err)
+	}
 	// We need to carefully craft the update request if a user
 	// wants to delete their filterCriterias. Mainly because the
 	// aws-sdk-go doesn't try to update nil fields.
@@ -1026,6 +1243,14 @@ func (rm *resourceManager) sdkUpdate(
 		}
 	}
 
+	// Same goes for the metrics configuration: removing the field
+	// from the spec has to be sent as an empty list of metrics.
+	if metricsConfigDeleted(latest, desired, delta) {
+		input.MetricsConfig = &svcsdktypes.EventSourceMappingMetricsConfig{
+			Metrics: []svcsdktypes.EventSourceMappingMetric{},
+		}
+	}
+
 	var resp *svcsdk.UpdateEventSourceMappingOutput
 	_ = resp
 	resp, err = rm.sdkapi.UpdateEventSourceMapping(ctx, input)
@@ -1112,6 +1337,21 @@ func (rm *resourceManager) sdkUpdate(
 	} else {
 		ko.Spec.DestinationConfig = nil
 	}
+	if resp.DocumentDBEventSourceConfig != nil {
+		f4 := &svcapitypes.DocumentDBEventSourceConfig{}
+		if resp.DocumentDBEventSourceConfig.CollectionName != nil {
+			f4.CollectionName = resp.DocumentDBEventSourceConfig.CollectionName
+		}
+		if resp.DocumentDBEventSourceConfig.DatabaseName != nil {
+			f4.DatabaseName = resp.DocumentDBEventSourceConfig.DatabaseName
+		}
+		if resp.DocumentDBEventSourceConfig.FullDocument != "" {
+			f4.FullDocument = aws.String(string(resp.DocumentDBEventSourceConfig.FullDocument))
+		}
+		ko.Spec.DocumentDBEventSourceConfig = f4
+	} else {
+		ko.Spec.DocumentDBEventSourceConfig = nil
+	}
 	if resp.EventSourceArn != nil {
 		ko.Spec.EventSourceARN = resp.EventSourceArn
 	} else {
@@ -1141,6 +1381,18 @@ func (rm *resourceManager) sdkUpdate(
 	} else {
 		ko.Spec.FilterCriteria = nil
 	}
+	if resp.FilterCriteriaError != nil {
+		f8 := &svcapitypes.FilterCriteriaError{}
+		if resp.FilterCriteriaError.ErrorCode != nil {
+			f8.ErrorCode = resp.FilterCriteriaError.ErrorCode
+		}
+		if resp.FilterCriteriaError.Message != nil {
+			f8.Message = resp.FilterCriteriaError.Message
+		}
+		ko.Status.FilterCriteriaError = f8
+	} else {
+		ko.Status.FilterCriteriaError = nil
+	}
 	if resp.FunctionArn != nil {
 		ko.Status.FunctionARN = resp.FunctionArn
 	} else {
@@ -1157,6 +1409,11 @@ func (rm *resourceManager) sdkUpdate(
 	} else {
 		ko.Spec.FunctionResponseTypes = nil
 	}
+	if resp.KMSKeyArn != nil {
+		ko.Spec.KMSKeyARN = resp.KMSKeyArn
+	} else {
+		ko.Spec.KMSKeyARN = nil
+	}
 	if resp.LastModified != nil {
 		ko.Status.LastModified = &metav1.Time{*resp.LastModified}
 	} else {
@@ -1194,12 +1451,44 @@ func (rm *resourceManager) sdkUpdate(
 	} else {
 		ko.Spec.MaximumRetryAttempts = nil
 	}
+	if resp.MetricsConfig != nil {
+		f18 := &svcapitypes.EventSourceMappingMetricsConfig{}
+		if resp.MetricsConfig.Metrics != nil {
+			f18f0 := []*string{}
+			for _, f18f0iter := range resp.MetricsConfig.Metrics {
+				var f18f0elem *string
+				f18f0elem = aws.String(string(f18f0iter))
+				f18f0 = append(f18f0, f18f0elem)
+			}
+			f18.Metrics = f18f0
+		}
+		ko.Spec.MetricsConfig = f18
+	} else {
+		ko.Spec.MetricsConfig = nil
+	}
 	if resp.ParallelizationFactor != nil {
 		parallelizationFactorCopy := int64(*resp.ParallelizationFactor)
 		ko.Spec.ParallelizationFactor = &parallelizationFactorCopy
 	} else {
 		ko.Spec.ParallelizationFactor = nil
 	}
+	if resp.ProvisionedPollerConfig != nil {
+		f20 := &svcapitypes.ProvisionedPollerConfig{}
+		if resp.ProvisionedPollerConfig.MaximumPollers != nil {
+			maximumPollersCopy := int64(*resp.ProvisionedPollerConfig.MaximumPollers)
+			f20.MaximumPollers = &maximumPollersCopy
+		}
+		if resp.ProvisionedPollerConfig.MinimumPollers != nil {
+			minimumPollersCopy := int64(*resp.ProvisionedPollerConfig.MinimumPollers)
+			f20.MinimumPollers = &minimumPollersCopy
+		}
+		if resp.ProvisionedPollerConfig.PollerGroupName != nil {
+			f20.PollerGroupName = resp.ProvisionedPollerConfig.PollerGroupName
+		}
+		ko.Spec.ProvisionedPollerConfig = f20
+	} else {
+		ko.Spec.ProvisionedPollerConfig = nil
+	}
 	if resp.Queues != nil {
 		ko.Spec.Queues = aws.StringSlice(resp.Queues)
 	} else {
@@ -1326,6 +1615,8 @@ func (rm *resourceManager) sdkUpdate(
 	}
 
 	rm.setStatusDefaults(ko)
+	setFilterCriteriaErrorCondition(&resource{ko})
+	restoreFilterRules(desired.ko, ko)
 	return &resource{ko}, nil
 }
 
@@ -1409,6 +1700,19 @@ func (rm *resourceManager) newUpdateRequestPayload(
 		}
 		res.DestinationConfig = f3
 	}
+	if r.ko.Spec.DocumentDBEventSourceConfig != nil {
+		f4 := &svcsdktypes.DocumentDBEventSourceConfig{}
+		if r.ko.Spec.DocumentDBEventSourceConfig.CollectionName != nil {
+			f4.CollectionName = r.ko.Spec.DocumentDBEventSourceConfig.CollectionName
+		}
+		if r.ko.Spec.DocumentDBEventSourceConfig.DatabaseName != nil {
+			f4.DatabaseName = r.ko.Spec.DocumentDBEventSourceConfig.DatabaseName
+		}
+		if r.ko.Spec.DocumentDBEventSourceConfig.FullDocument != nil {
+			f4.FullDocument = svcsdktypes.FullDocument(*r.ko.Spec.DocumentDBEventSourceConfig.FullDocument)
+		}
+		res.DocumentDBEventSourceConfig = f4
+	}
 	if r.ko.Spec.Enabled != nil {
 		res.Enabled = r.ko.Spec.Enabled
 	}
@@ -1439,6 +1743,9 @@ func (rm *resourceManager) newUpdateRequestPayload(
 		}
 		res.FunctionResponseTypes = f8
 	}
+	if r.ko.Spec.KMSKeyARN != nil {
+		res.KMSKeyArn = r.ko.Spec.KMSKeyARN
+	}
 	if r.ko.Spec.LoggingConfig != nil {
 		f10 := &svcsdktypes.EventSourceMappingLoggingConfig{}
 		if r.ko.Spec.LoggingConfig.SystemLogLevel != nil {
@@ -1470,6 +1777,19 @@ func (rm *resourceManager) newUpdateRequestPayload(
 		maximumRetryAttemptsCopy := int32(maximumRetryAttemptsCopy0)
 		res.MaximumRetryAttempts = &maximumRetryAttemptsCopy
 	}
+	if r.ko.Spec.MetricsConfig != nil {
+		f14 := &svcsdktypes.EventSourceMappingMetricsConfig{}
+		if r.ko.Spec.MetricsConfig.Metrics != nil {
+			f14f0 := []svcsdktypes.EventSourceMappingMetric{}
+			for _, f14f0iter := range r.ko.Spec.MetricsConfig.Metrics {
+				var f14f0elem string
+				f14f0elem = string(*f14f0iter)
+				f14f0 = append(f14f0, svcsdktypes.EventSourceMappingMetric(f14f0elem))
+			}
+			f14.Metrics = f14f0
+		}
+		res.MetricsConfig = f14
+	}
 	if r.ko.Spec.ParallelizationFactor != nil {
 		parallelizationFactorCopy0 := *r.ko.Spec.ParallelizationFactor
 		if parallelizationFactorCopy0 > math.MaxInt32 || parallelizationFactorCopy0 < math.MinInt32 {
@@ -1478,6 +1798,29 @@ func (rm *resourceManager) newUpdateRequestPayload(
 		parallelizationFactorCopy := int32(parallelizationFactorCopy0)
 		res.ParallelizationFactor = &parallelizationFactorCopy
 	}
+	if r.ko.Spec.ProvisionedPollerConfig != nil {
+		f16 := &svcsdktypes.ProvisionedPollerConfig{}
+		if r.ko.Spec.ProvisionedPollerConfig.MaximumPollers != nil {
+			maximumPollersCopy0 := *r.ko.Spec.ProvisionedPollerConfig.MaximumPollers
+			if maximumPollersCopy0 > math.MaxInt32 || maximumPollersCopy0 < math.MinInt32 {
+				return nil, fmt.Errorf("error: field MaximumPollers is of type int32")
+			}
+			maximumPollersCopy := int32(maximumPollersCopy0)
+			f16.MaximumPollers = &maximumPollersCopy
+		}
+		if r.ko.Spec.ProvisionedPollerConfig.MinimumPollers != nil {
+			minimumPollersCopy0 := *r.ko.Spec.ProvisionedPollerConfig.MinimumPollers
+			if minimumPollersCopy0 > math.MaxInt32 || minimumPollersCopy0 < math.MinInt32 {
+				return nil, fmt.Errorf("error: field MinimumPollers is of type int32")
+			}
+			minimumPollersCopy := int32(minimumPollersCopy0)
+			f16.MinimumPollers = &minimumPollersCopy
+		}
+		if r.ko.Spec.ProvisionedPollerConfig.PollerGroupName != nil {
+			f16.PollerGroupName = r.ko.Spec.ProvisionedPollerConfig.PollerGroupName
+		}
+		res.ProvisionedPollerConfig = f16
+	}
 	if r.ko.Spec.ScalingConfig != nil {
 		f17 := &svcsdktypes.ScalingConfig{}
 		if r.ko.Spec.ScalingConfig.MaximumConcurrency != nil {
@@ -1571,6 +1914,9 @@ func (rm *resourceManager) sdkDelete(
 	defer func() {
 		exit(err)
 	}()
+	if isTransitioning(r) {
+		return r, requeueWaitWhileTransitioning
+	}
 	input, err := rm.newDeleteRequestPayload(r)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,145 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package event_source_mapping
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConditionTypeDegraded is raised on an EventSourceMapping that Lambda
+// disabled, or whose last processing attempt reported a problem. It is set
+// to False, with the UserInitiated reason, when the mapping was disabled by
+// a user.
+const ConditionTypeDegraded ackv1alpha1.ConditionType = "Degraded"
+
+const (
+	degradedReasonDisabledByLambda  = "DisabledByLambda"
+	degradedReasonProcessingProblem = "ProcessingProblem"
+	degradedReasonUserInitiated     = "UserInitiated"
+	degradedReasonHealthy           = "Healthy"
+)
+
+// Event source mapping states. The mapping can only be modified or deleted
+// once it is Enabled or Disabled.
+const (
+	stateCreating  = "Creating"
+	stateEnabling  = "Enabling"
+	stateDisabling = "Disabling"
+	stateDisabled  = "Disabled"
+	stateUpdating  = "Updating"
+	stateDeleting  = "Deleting"
+)
+
+var (
+	ErrEventSourceMappingTransitioning = errors.New("event source mapping is transitioning between states, cannot be modified or deleted")
+)
+
+var (
+	requeueWaitWhileTransitioning = ackrequeue.NeededAfter(
+		ErrEventSourceMappingTransitioning,
+		5*time.Second,
+	)
+)
+
+// isTransitioning returns true if the supplied event source mapping is
+// moving between states, and can't be modified or deleted until it settles.
+func isTransitioning(r *resource) bool {
+	if r.ko.Status.State == nil {
+		return false
+	}
+	switch *r.ko.Status.State {
+	case stateCreating, stateEnabling, stateDisabling, stateUpdating, stateDeleting:
+		return true
+	}
+	return false
+}
+
+// isUserInitiated returns true if the state transition reason of an event
+// source mapping says the last change was made by a user, for example
+// "USER_INITIATED" or "User action".
+func isUserInitiated(reason *string) bool {
+	if reason == nil {
+		return false
+	}
+	r := strings.ToLower(*reason)
+	return strings.Contains(r, "user_initiated") || strings.Contains(r, "user action")
+}
+
+// setDegradedCondition sets the Degraded condition of an event source
+// mapping from its observed state, state transition reason and last
+// processing result.
+//
+// This function is used as a sdk_read_one_post_set_output hook.
+func setDegradedCondition(r *resource) {
+	status := corev1.ConditionFalse
+	reason := degradedReasonHealthy
+	message := "event source mapping is processing records normally"
+
+	state := r.ko.Status.State
+	transitionReason := r.ko.Status.StateTransitionReason
+	lastResult := r.ko.Status.LastProcessingResult
+	switch {
+	case state != nil && *state == stateDisabled && isUserInitiated(transitionReason):
+		reason = degradedReasonUserInitiated
+		message = "event source mapping was disabled by a user"
+	case state != nil && *state == stateDisabled && transitionReason != nil && *transitionReason != "":
+		status = corev1.ConditionTrue
+		reason = degradedReasonDisabledByLambda
+		message = fmt.Sprintf("event source mapping was disabled by Lambda: %s", *transitionReason)
+	case lastResult != nil && strings.HasPrefix(*lastResult, "PROBLEM"):
+		status = corev1.ConditionTrue
+		reason = degradedReasonProcessingProblem
+		message = fmt.Sprintf("last processing attempt failed: %s", *lastResult)
+	}
+
+	setCondition(r, ConditionTypeDegraded, status, reason, message)
+}
+
+// setCondition sets a condition of the supplied type on the supplied event
+// source mapping.
+func setCondition(
+	r *resource,
+	conditionType ackv1alpha1.ConditionType,
+	status corev1.ConditionStatus,
+	reason string,
+	message string,
+) {
+	if cond := ackcondition.FirstOfType(r, conditionType); cond != nil {
+		if cond.Status != status {
+			now := metav1.Now()
+			cond.LastTransitionTime = &now
+		}
+		cond.Status = status
+		cond.Reason = &reason
+		cond.Message = &message
+		return
+	}
+	now := metav1.Now()
+	r.ReplaceConditions(append(r.Conditions(), &ackv1alpha1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		LastTransitionTime: &now,
+		Reason:             &reason,
+		Message:            &message,
+	}))
+}
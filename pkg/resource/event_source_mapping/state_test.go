@@ -0,0 +1,85 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package event_source_mapping
+
+import (
+	"testing"
+
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+)
+
+func Test_setDegradedCondition(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     v1alpha1.EventSourceMappingStatus
+		wantStatus corev1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name: "enabled",
+			status: v1alpha1.EventSourceMappingStatus{
+				State:                aws.String("Enabled"),
+				LastProcessingResult: aws.String("OK"),
+			},
+			wantStatus: corev1.ConditionFalse,
+			wantReason: degradedReasonHealthy,
+		},
+		{
+			name: "disabled by a user",
+			status: v1alpha1.EventSourceMappingStatus{
+				State:                 aws.String("Disabled"),
+				StateTransitionReason: aws.String("USER_INITIATED"),
+			},
+			wantStatus: corev1.ConditionFalse,
+			wantReason: degradedReasonUserInitiated,
+		},
+		{
+			name: "disabled by lambda",
+			status: v1alpha1.EventSourceMappingStatus{
+				State:                 aws.String("Disabled"),
+				StateTransitionReason: aws.String("PROBLEM: Function does not exist"),
+			},
+			wantStatus: corev1.ConditionTrue,
+			wantReason: degradedReasonDisabledByLambda,
+		},
+		{
+			name: "processing problem",
+			status: v1alpha1.EventSourceMappingStatus{
+				State:                aws.String("Enabled"),
+				LastProcessingResult: aws.String("PROBLEM: Function call failed"),
+			},
+			wantStatus: corev1.ConditionTrue,
+			wantReason: degradedReasonProcessingProblem,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &resource{ko: &v1alpha1.EventSourceMapping{Status: tt.status}}
+			setDegradedCondition(r)
+			// Setting the condition twice must not duplicate it.
+			setDegradedCondition(r)
+			if len(r.ko.Status.Conditions) != 1 {
+				t.Fatalf("expected a single condition, got %d", len(r.ko.Status.Conditions))
+			}
+			cond := ackcondition.FirstOfType(r, ConditionTypeDegraded)
+			if cond.Status != tt.wantStatus || *cond.Reason != tt.wantReason {
+				t.Errorf("Degraded = %s/%s, want %s/%s", cond.Status, *cond.Reason, tt.wantStatus, tt.wantReason)
+			}
+		})
+	}
+}
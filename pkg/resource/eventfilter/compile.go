@@ -0,0 +1,201 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package eventfilter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+)
+
+var (
+	ErrNoRules             = errors.New("at least one rule is required")
+	ErrInvalidPath         = errors.New("path must be a dot-separated list of non-empty field names")
+	ErrConflictingPaths    = errors.New("path is both a field and the parent of another rule's field")
+	ErrNoOperator          = errors.New("one of values, prefix, numeric, exists or anythingBut is required")
+	ErrMultipleOperators   = errors.New("only one of values, prefix, numeric, exists or anythingBut can be set")
+	ErrEmptyNumericRange   = errors.New("numeric requires equals or at least one bound")
+	ErrInvalidNumericRange = errors.New("numeric equals can't be combined with bounds, and each side takes a single bound")
+	ErrEmptyAnythingBut    = errors.New("anythingBut requires at least one value")
+	ErrEmptyValues         = errors.New("values requires at least one value")
+	ErrNilConditionValue   = errors.New("values can't contain null entries")
+)
+
+// Compile returns the JSON filter pattern equivalent to the given rules.
+// The keys of the returned pattern are sorted, so that compiling the same
+// rules always gives the same pattern.
+func Compile(rules []*svcapitypes.FilterRule) (string, error) {
+	if len(rules) == 0 {
+		return "", fmt.Errorf("rules: %w", ErrNoRules)
+	}
+	pattern := map[string]interface{}{}
+	for i, rule := range rules {
+		if rule == nil {
+			return "", fmt.Errorf("rules[%d]: %w", i, ErrNoOperator)
+		}
+		if err := addRule(pattern, rule); err != nil {
+			return "", fmt.Errorf("rules[%d]: %w", i, err)
+		}
+	}
+	// Numeric operators are kept as is rather than escaped as HTML.
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(pattern); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
+
+// addRule adds the condition of a rule to the pattern, under the nested
+// objects named by its path.
+func addRule(pattern map[string]interface{}, rule *svcapitypes.FilterRule) error {
+	if rule.Path == nil {
+		return ErrInvalidPath
+	}
+	fields := strings.Split(*rule.Path, ".")
+	for _, field := range fields {
+		if field == "" {
+			return ErrInvalidPath
+		}
+	}
+	condition, err := ruleCondition(rule)
+	if err != nil {
+		return err
+	}
+
+	parent := pattern
+	for _, field := range fields[:len(fields)-1] {
+		switch child := parent[field].(type) {
+		case nil:
+			object := map[string]interface{}{}
+			parent[field] = object
+			parent = object
+		case map[string]interface{}:
+			parent = child
+		default:
+			return ErrConflictingPaths
+		}
+	}
+	leaf := fields[len(fields)-1]
+	switch conditions := parent[leaf].(type) {
+	case nil:
+		parent[leaf] = condition
+	case []interface{}:
+		parent[leaf] = append(conditions, condition...)
+	default:
+		return ErrConflictingPaths
+	}
+	return nil
+}
+
+// ruleCondition returns the list of pattern conditions of a rule.
+func ruleCondition(rule *svcapitypes.FilterRule) ([]interface{}, error) {
+	operators := 0
+	for _, set := range []bool{
+		rule.Values != nil,
+		rule.Prefix != nil,
+		rule.Numeric != nil,
+		rule.Exists != nil,
+		rule.AnythingBut != nil,
+	} {
+		if set {
+			operators++
+		}
+	}
+	if operators == 0 {
+		return nil, ErrNoOperator
+	}
+	if operators > 1 {
+		return nil, ErrMultipleOperators
+	}
+
+	switch {
+	case rule.Values != nil:
+		if len(rule.Values) == 0 {
+			return nil, ErrEmptyValues
+		}
+		values, err := stringValues(rule.Values)
+		if err != nil {
+			return nil, err
+		}
+		return values, nil
+	case rule.Prefix != nil:
+		return []interface{}{map[string]interface{}{"prefix": *rule.Prefix}}, nil
+	case rule.Numeric != nil:
+		numeric, err := numericCondition(rule.Numeric)
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{map[string]interface{}{"numeric": numeric}}, nil
+	case rule.Exists != nil:
+		return []interface{}{map[string]interface{}{"exists": *rule.Exists}}, nil
+	default:
+		if len(rule.AnythingBut) == 0 {
+			return nil, ErrEmptyAnythingBut
+		}
+		values, err := stringValues(rule.AnythingBut)
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{map[string]interface{}{"anything-but": values}}, nil
+	}
+}
+
+// numericCondition returns the operands of a numeric condition, for example
+// [">", 0, "<=", 5].
+func numericCondition(r *svcapitypes.FilterNumericRange) ([]interface{}, error) {
+	if r.Equals != nil {
+		if r.GreaterThan != nil || r.GreaterThanOrEqual != nil ||
+			r.LessThan != nil || r.LessThanOrEqual != nil {
+			return nil, ErrInvalidNumericRange
+		}
+		return []interface{}{"=", *r.Equals}, nil
+	}
+	if (r.GreaterThan != nil && r.GreaterThanOrEqual != nil) ||
+		(r.LessThan != nil && r.LessThanOrEqual != nil) {
+		return nil, ErrInvalidNumericRange
+	}
+	numeric := []interface{}{}
+	if r.GreaterThan != nil {
+		numeric = append(numeric, ">", *r.GreaterThan)
+	}
+	if r.GreaterThanOrEqual != nil {
+		numeric = append(numeric, ">=", *r.GreaterThanOrEqual)
+	}
+	if r.LessThan != nil {
+		numeric = append(numeric, "<", *r.LessThan)
+	}
+	if r.LessThanOrEqual != nil {
+		numeric = append(numeric, "<=", *r.LessThanOrEqual)
+	}
+	if len(numeric) == 0 {
+		return nil, ErrEmptyNumericRange
+	}
+	return numeric, nil
+}
+
+func stringValues(values []*string) ([]interface{}, error) {
+	res := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		if v == nil {
+			return nil, ErrNilConditionValue
+		}
+		res = append(res, *v)
+	}
+	return res, nil
+}
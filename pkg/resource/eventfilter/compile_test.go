@@ -0,0 +1,112 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package eventfilter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+)
+
+func Test_Compile(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   []*svcapitypes.FilterRule
+		want    string
+		wantErr error
+	}{
+		{
+			name: "values",
+			rules: []*svcapitypes.FilterRule{
+				{Path: aws.String("body.status"), Values: []*string{aws.String("active"), aws.String("pending")}},
+			},
+			want: `{"body":{"status":["active","pending"]}}`,
+		},
+		{
+			name: "operators on different paths",
+			rules: []*svcapitypes.FilterRule{
+				{Path: aws.String("body.id"), Prefix: aws.String("order-")},
+				{Path: aws.String("body.quantity"), Numeric: &svcapitypes.FilterNumericRange{
+					GreaterThan: aws.Float64(0), LessThanOrEqual: aws.Float64(5),
+				}},
+				{Path: aws.String("body.coupon"), Exists: aws.Bool(false)},
+				{Path: aws.String("eventName"), AnythingBut: []*string{aws.String("REMOVE")}},
+			},
+			want: `{"body":{"coupon":[{"exists":false}],"id":[{"prefix":"order-"}],"quantity":[{"numeric":[">",0,"<=",5]}]},"eventName":[{"anything-but":["REMOVE"]}]}`,
+		},
+		{
+			name: "rules on the same path",
+			rules: []*svcapitypes.FilterRule{
+				{Path: aws.String("body.price"), Numeric: &svcapitypes.FilterNumericRange{Equals: aws.Float64(10)}},
+				{Path: aws.String("body.price"), Exists: aws.Bool(false)},
+			},
+			want: `{"body":{"price":[{"numeric":["=",10]},{"exists":false}]}}`,
+		},
+		{
+			name:    "no rules",
+			wantErr: ErrNoRules,
+		},
+		{
+			name: "empty path segment",
+			rules: []*svcapitypes.FilterRule{
+				{Path: aws.String("body..status"), Values: []*string{aws.String("active")}},
+			},
+			wantErr: ErrInvalidPath,
+		},
+		{
+			name: "conflicting paths",
+			rules: []*svcapitypes.FilterRule{
+				{Path: aws.String("body"), Exists: aws.Bool(true)},
+				{Path: aws.String("body.status"), Values: []*string{aws.String("active")}},
+			},
+			wantErr: ErrConflictingPaths,
+		},
+		{
+			name: "multiple operators",
+			rules: []*svcapitypes.FilterRule{
+				{Path: aws.String("body.status"), Prefix: aws.String("a"), Exists: aws.Bool(true)},
+			},
+			wantErr: ErrMultipleOperators,
+		},
+		{
+			name: "equals with bounds",
+			rules: []*svcapitypes.FilterRule{
+				{Path: aws.String("body.price"), Numeric: &svcapitypes.FilterNumericRange{
+					Equals: aws.Float64(10), LessThan: aws.Float64(20),
+				}},
+			},
+			wantErr: ErrInvalidNumericRange,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Compile(tt.rules)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Compile() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Compile() unexpected error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Compile() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package eventfilter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Match returns whether a record matches a JSON filter pattern, following
+// the Lambda event filtering rules. The record is the document Lambda
+// evaluates patterns against, as returned by FilterableRecord.
+//
+// Pattern fields are all required to match. A field matches when any of
+// its conditions matches the record value, or any element of it when the
+// value is an array. The $or field matches when any of its patterns does.
+func Match(pattern string, record map[string]interface{}) (bool, error) {
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(pattern), &parsed); err != nil {
+		return false, fmt.Errorf("invalid pattern: %w", err)
+	}
+	return matchObject(parsed, record)
+}
+
+func matchObject(pattern map[string]interface{}, record map[string]interface{}) (bool, error) {
+	for field, p := range pattern {
+		value, present := record[field]
+		var matched bool
+		var err error
+		switch p := p.(type) {
+		case map[string]interface{}:
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				// Only exists: false conditions can match a missing
+				// object, compare them with an empty one.
+				object = map[string]interface{}{}
+			}
+			matched, err = matchObject(p, object)
+		case []interface{}:
+			if field == "$or" {
+				matched, err = matchAny(p, record)
+			} else {
+				matched, err = matchConditions(p, value, present)
+			}
+		default:
+			return false, fmt.Errorf("field %q: pattern values must be objects or arrays", field)
+		}
+		if err != nil {
+			return false, fmt.Errorf("field %q: %w", field, err)
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// matchAny matches the patterns of a $or field.
+func matchAny(patterns []interface{}, record map[string]interface{}) (bool, error) {
+	for _, p := range patterns {
+		object, ok := p.(map[string]interface{})
+		if !ok {
+			return false, fmt.Errorf("$or must be an array of objects")
+		}
+		matched, err := matchObject(object, record)
+		if err != nil || matched {
+			return matched, err
+		}
+	}
+	return false, nil
+}
+
+func matchConditions(conditions []interface{}, value interface{}, present bool) (bool, error) {
+	values := []interface{}{value}
+	if array, ok := value.([]interface{}); ok {
+		values = array
+	}
+	for _, condition := range conditions {
+		operator, ok := condition.(map[string]interface{})
+		if !ok {
+			if present && containsValue(values, condition) {
+				return true, nil
+			}
+			continue
+		}
+		if exists, ok := operator["exists"]; ok {
+			b, ok := exists.(bool)
+			if !ok {
+				return false, fmt.Errorf("exists takes a boolean")
+			}
+			if b == present {
+				return true, nil
+			}
+			continue
+		}
+		if !present {
+			continue
+		}
+		for _, v := range values {
+			matched, err := matchOperator(operator, v)
+			if err != nil || matched {
+				return matched, err
+			}
+		}
+	}
+	return false, nil
+}
+
+// matchOperator evaluates a content filtering condition, for example
+// {"prefix": "order-"}, against a single value.
+func matchOperator(operator map[string]interface{}, value interface{}) (bool, error) {
+	if len(operator) != 1 {
+		return false, fmt.Errorf("conditions take a single operator")
+	}
+	for name, operand := range operator {
+		switch name {
+		case "prefix", "suffix":
+			return matchAffix(name, operand, value)
+		case "equals-ignore-case":
+			s, ok := value.(string)
+			o, okOperand := operand.(string)
+			if !okOperand {
+				return false, fmt.Errorf("equals-ignore-case takes a string")
+			}
+			return ok && strings.EqualFold(s, o), nil
+		case "anything-but":
+			return matchAnythingBut(operand, value)
+		case "numeric":
+			return matchNumeric(operand, value)
+		default:
+			return false, fmt.Errorf("operator %q isn't supported by Lambda event filtering", name)
+		}
+	}
+	return false, nil
+}
+
+func matchAffix(name string, operand interface{}, value interface{}) (bool, error) {
+	s, ok := value.(string)
+	ignoreCase := false
+	if object, isObject := operand.(map[string]interface{}); isObject {
+		operand, ignoreCase = object["equals-ignore-case"], true
+	}
+	affix, okOperand := operand.(string)
+	if !okOperand {
+		return false, fmt.Errorf("%s takes a string", name)
+	}
+	if !ok {
+		return false, nil
+	}
+	if ignoreCase {
+		s, affix = strings.ToLower(s), strings.ToLower(affix)
+	}
+	if name == "prefix" {
+		return strings.HasPrefix(s, affix), nil
+	}
+	return strings.HasSuffix(s, affix), nil
+}
+
+func matchAnythingBut(operand interface{}, value interface{}) (bool, error) {
+	switch operand := operand.(type) {
+	case []interface{}:
+		return !containsValue(operand, value), nil
+	case map[string]interface{}:
+		matched, err := matchOperator(operand, value)
+		return !matched, err
+	default:
+		return !equalValues(operand, value), nil
+	}
+}
+
+func matchNumeric(operand interface{}, value interface{}) (bool, error) {
+	operands, ok := operand.([]interface{})
+	if !ok || len(operands) == 0 || len(operands)%2 != 0 {
+		return false, fmt.Errorf("numeric takes pairs of operators and numbers")
+	}
+	n, ok := value.(float64)
+	if !ok {
+		return false, nil
+	}
+	for i := 0; i < len(operands); i += 2 {
+		op, _ := operands[i].(string)
+		bound, ok := operands[i+1].(float64)
+		if !ok {
+			return false, fmt.Errorf("numeric takes pairs of operators and numbers")
+		}
+		var matched bool
+		switch op {
+		case "=":
+			matched = n == bound
+		case ">":
+			matched = n > bound
+		case ">=":
+			matched = n >= bound
+		case "<":
+			matched = n < bound
+		case "<=":
+			matched = n <= bound
+		default:
+			return false, fmt.Errorf("unknown numeric operator %q", op)
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if equalValues(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// equalValues compares JSON scalars. Numbers are decoded as float64, so
+// 10 and 10.0 are equal.
+func equalValues(a, b interface{}) bool {
+	switch a.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	}
+	switch b.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	}
+	return a == b
+}
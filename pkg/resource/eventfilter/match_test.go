@@ -0,0 +1,125 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package eventfilter
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+)
+
+func Test_Match(t *testing.T) {
+	record := `{
+		"eventName": "INSERT",
+		"body": {
+			"id": "order-1234",
+			"status": "Active",
+			"quantity": 12,
+			"tags": ["gift", "express"],
+			"coupon": null
+		}
+	}`
+	tests := []struct {
+		name    string
+		pattern string
+		want    bool
+		wantErr bool
+	}{
+		{name: "equals", pattern: `{"eventName":["INSERT","MODIFY"]}`, want: true},
+		{name: "equals mismatch", pattern: `{"eventName":["REMOVE"]}`, want: false},
+		{name: "nested fields are all required", pattern: `{"eventName":["INSERT"],"body":{"id":["order-0"]}}`, want: false},
+		{name: "array value", pattern: `{"body":{"tags":["express"]}}`, want: true},
+		{name: "null", pattern: `{"body":{"coupon":[null]}}`, want: true},
+		{name: "prefix", pattern: `{"body":{"id":[{"prefix":"order-"}]}}`, want: true},
+		{name: "suffix", pattern: `{"body":{"id":[{"suffix":"34"}]}}`, want: true},
+		{name: "prefix ignoring case", pattern: `{"body":{"id":[{"prefix":{"equals-ignore-case":"ORDER-"}}]}}`, want: true},
+		{name: "equals ignoring case", pattern: `{"body":{"status":[{"equals-ignore-case":"active"}]}}`, want: true},
+		{name: "numeric range", pattern: `{"body":{"quantity":[{"numeric":[">",10,"<=",12]}]}}`, want: true},
+		{name: "numeric out of range", pattern: `{"body":{"quantity":[{"numeric":["<",10]}]}}`, want: false},
+		{name: "exists", pattern: `{"body":{"id":[{"exists":true}]}}`, want: true},
+		{name: "does not exist", pattern: `{"body":{"discount":[{"exists":false}]}}`, want: true},
+		{name: "does not exist in missing object", pattern: `{"metadata":{"discount":[{"exists":false}]}}`, want: true},
+		{name: "anything but", pattern: `{"eventName":[{"anything-but":["REMOVE"]}]}`, want: true},
+		{name: "anything but prefix", pattern: `{"body":{"id":[{"anything-but":{"prefix":"order-"}}]}}`, want: false},
+		{name: "or", pattern: `{"$or":[{"eventName":["REMOVE"]},{"body":{"status":["Active"]}}]}`, want: true},
+		{name: "missing field", pattern: `{"body":{"discount":[10]}}`, want: false},
+		{name: "unsupported operator", pattern: `{"body":{"id":[{"wildcard":"order-*"}]}}`, wantErr: true},
+		{name: "scalar pattern value", pattern: `{"eventName":"INSERT"}`, wantErr: true},
+	}
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(record), &parsed); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Match(tt.pattern, parsed)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Match() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_FilterableRecord(t *testing.T) {
+	data := base64.StdEncoding.EncodeToString([]byte(`{"temperature":21}`))
+	tests := []struct {
+		name    string
+		record  string
+		pattern string
+	}{
+		{
+			name:    "sqs json body",
+			record:  `{"eventSource":"aws:sqs","body":"{\"status\":\"active\"}"}`,
+			pattern: `{"body":{"status":["active"]}}`,
+		},
+		{
+			name:    "sqs plain body",
+			record:  `{"eventSource":"aws:sqs","body":"active"}`,
+			pattern: `{"body":["active"]}`,
+		},
+		{
+			name:    "kinesis",
+			record:  `{"eventSource":"aws:kinesis","kinesis":{"partitionKey":"1","data":"` + data + `"}}`,
+			pattern: `{"partitionKey":["1"],"data":{"temperature":[{"numeric":[">",20]}]}}`,
+		},
+		{
+			name:    "dynamodb",
+			record:  `{"eventSource":"aws:dynamodb","eventName":"INSERT","dynamodb":{"NewImage":{"id":{"S":"1"}}}}`,
+			pattern: `{"dynamodb":{"NewImage":{"id":{"S":["1"]}}}}`,
+		},
+		{
+			name:    "kafka",
+			record:  `{"topic":"orders","partition":0,"offset":15,"value":"` + data + `"}`,
+			pattern: `{"value":{"temperature":[21]}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var record map[string]interface{}
+			if err := json.Unmarshal([]byte(tt.record), &record); err != nil {
+				t.Fatal(err)
+			}
+			got, err := Match(tt.pattern, FilterableRecord(record))
+			if err != nil {
+				t.Fatalf("Match() unexpected error = %v", err)
+			}
+			if !got {
+				t.Errorf("expected %s to match %s", tt.pattern, tt.record)
+			}
+		})
+	}
+}
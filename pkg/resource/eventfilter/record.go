@@ -0,0 +1,133 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package eventfilter
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Records returns the records of a sample document. The document is either
+// a Lambda event, with a Records array (Amazon SQS, Kinesis and DynamoDB
+// Streams) or a records map keyed by topic partition (Amazon MSK and
+// self-managed Apache Kafka), an array of records, or a single record.
+func Records(document interface{}) ([]map[string]interface{}, error) {
+	switch document := document.(type) {
+	case []interface{}:
+		return recordList(document)
+	case map[string]interface{}:
+		if records, ok := document["Records"].([]interface{}); ok {
+			return recordList(records)
+		}
+		if partitions, ok := document["records"].(map[string]interface{}); ok {
+			keys := make([]string, 0, len(partitions))
+			for key := range partitions {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			res := []map[string]interface{}{}
+			for _, key := range keys {
+				records, ok := partitions[key].([]interface{})
+				if !ok {
+					return nil, fmt.Errorf("records of %q must be an array", key)
+				}
+				partition, err := recordList(records)
+				if err != nil {
+					return nil, err
+				}
+				res = append(res, partition...)
+			}
+			return res, nil
+		}
+		return []map[string]interface{}{document}, nil
+	default:
+		return nil, fmt.Errorf("expected a JSON object or array")
+	}
+}
+
+func recordList(records []interface{}) ([]map[string]interface{}, error) {
+	res := make([]map[string]interface{}, 0, len(records))
+	for i, r := range records {
+		record, ok := r.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("record %d must be a JSON object", i)
+		}
+		res = append(res, record)
+	}
+	return res, nil
+}
+
+// FilterableRecord returns the document Lambda evaluates filter patterns
+// against for a record:
+//
+//   - Amazon SQS: the body is parsed when it is a JSON object.
+//   - Kinesis: the fields of the kinesis object are moved up, and the base64
+//     encoded data is decoded and parsed when it is a JSON object.
+//   - DynamoDB Streams: the record is used as is.
+//   - Amazon MSK and self-managed Apache Kafka: the base64 encoded value is
+//     decoded and parsed when it is a JSON object.
+//
+// Payloads that aren't JSON objects are left as they are, like Lambda does,
+// so only metadata fields can be matched for them.
+func FilterableRecord(record map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(record))
+	for k, v := range record {
+		res[k] = v
+	}
+	switch {
+	case record["eventSource"] == "aws:sqs":
+		if body, ok := record["body"].(string); ok {
+			if object, ok := jsonObject([]byte(body)); ok {
+				res["body"] = object
+			}
+		}
+	case record["eventSource"] == "aws:kinesis":
+		if kinesis, ok := record["kinesis"].(map[string]interface{}); ok {
+			delete(res, "kinesis")
+			for k, v := range kinesis {
+				res[k] = v
+			}
+			if object, ok := base64JSONObject(kinesis["data"]); ok {
+				res["data"] = object
+			}
+		}
+	case record["topic"] != nil && record["partition"] != nil:
+		if object, ok := base64JSONObject(record["value"]); ok {
+			res["value"] = object
+		}
+	}
+	return res
+}
+
+func base64JSONObject(v interface{}) (map[string]interface{}, bool) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, false
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, false
+	}
+	return jsonObject(b)
+}
+
+func jsonObject(b []byte) (map[string]interface{}, bool) {
+	var object map[string]interface{}
+	if err := json.Unmarshal(b, &object); err != nil || object == nil {
+		return nil, false
+	}
+	return object, true
+}
@@ -0,0 +1,188 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package function
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/lambda"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+	svcversion "github.com/aws-controllers-k8s/lambda-controller/pkg/resource/version"
+)
+
+// maxPublishedVersions bounds the number of published versions kept in the
+// auto-publish status.
+const maxPublishedVersions = 10
+
+var (
+	requeueWaitWhileUpdateInProgress = ackrequeue.NeededAfter(
+		svcversion.ErrFunctionUpdateInProgress,
+		5*time.Second,
+	)
+)
+
+// isFunctionUpdateInProgress returns true if the last update of the supplied
+// Lambda Function didn't finish yet.
+func isFunctionUpdateInProgress(r *resource) bool {
+	if r.ko.Status.LastUpdateStatus == nil {
+		return false
+	}
+	return *r.ko.Status.LastUpdateStatus == string(svcapitypes.LastUpdateStatus_InProgress)
+}
+
+// autoPublishPending returns true if the current revision of $LATEST wasn't
+// published yet, or if the auto-published alias doesn't point to the last
+// published version.
+func autoPublishPending(
+	alias *string,
+	latest *resource,
+) bool {
+	if alias == nil || isFunctionUpdateFailed(latest) {
+		return false
+	}
+	// Versions are only published once the smoke test passed.
+	if latest.ko.Spec.SmokeTest != nil && !smokeTestPassed(latest) {
+		return false
+	}
+	status := latest.ko.Status.AutoPublish
+	if status == nil || len(status.PublishedVersions) == 0 {
+		return true
+	}
+	last := status.PublishedVersions[len(status.PublishedVersions)-1]
+	if last.RevisionID == nil || latest.ko.Status.RevisionID == nil ||
+		*last.RevisionID != *latest.ko.Status.RevisionID {
+		return true
+	}
+	return status.AliasVersion == nil || last.Version == nil ||
+		*status.AliasVersion != *last.Version
+}
+
+// setAutoPublishAliasVersion sets the version the auto-published alias points
+// to. The published versions are kept from the supplied resource. It must
+// only be called when Spec.AutoPublishAlias is set.
+func (rm *resourceManager) setAutoPublishAliasVersion(
+	ctx context.Context,
+	ko *svcapitypes.Function,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.setAutoPublishAliasVersion")
+	defer exit(err)
+
+	if ko.Status.AutoPublish == nil {
+		ko.Status.AutoPublish = &svcapitypes.FunctionAutoPublishStatus{}
+	}
+
+	var resp *svcsdk.GetAliasOutput
+	resp, err = rm.sdkapi.GetAlias(ctx, &svcsdk.GetAliasInput{
+		FunctionName: ko.Spec.Name,
+		Name:         ko.Spec.AutoPublishAlias,
+	})
+	rm.metrics.RecordAPICall("READ_ONE", "GetAlias", err)
+	if err != nil {
+		var notFound *svcsdktypes.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			ko.Status.AutoPublish.AliasVersion = nil
+			return nil
+		}
+		return err
+	}
+	ko.Status.AutoPublish.AliasVersion = resp.FunctionVersion
+	return nil
+}
+
+// autoPublish publishes a version from the current revision of $LATEST and
+// points the auto-published alias to it, creating the alias if it doesn't
+// exist. It returns the auto-publish status to record.
+func (rm *resourceManager) autoPublish(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+) (status *svcapitypes.FunctionAutoPublishStatus, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.autoPublish")
+	defer exit(err)
+
+	status = &svcapitypes.FunctionAutoPublishStatus{}
+	if latest.ko.Status.AutoPublish != nil {
+		status = latest.ko.Status.AutoPublish.DeepCopy()
+	}
+
+	var resp *svcsdk.PublishVersionOutput
+	resp, err = rm.sdkapi.PublishVersion(ctx, &svcsdk.PublishVersionInput{
+		FunctionName: desired.ko.Spec.Name,
+		CodeSha256:   latest.ko.Status.CodeSHA256,
+		Description:  aws.String(fmt.Sprintf("CodeSHA256: %s", aws.ToString(latest.ko.Status.CodeSHA256))),
+		RevisionId:   latest.ko.Status.RevisionID,
+	})
+	rm.metrics.RecordAPICall("CREATE", "PublishVersion", err)
+	if err != nil {
+		return nil, err
+	}
+
+	var last *svcapitypes.FunctionPublishedVersion
+	if len(status.PublishedVersions) > 0 {
+		last = status.PublishedVersions[len(status.PublishedVersions)-1]
+	}
+	if last != nil && last.Version != nil && *last.Version == *resp.Version {
+		// No changes were made to $LATEST since the last version was
+		// published, only the revision changed.
+		last.RevisionID = latest.ko.Status.RevisionID
+	} else {
+		now := metav1.Now()
+		status.PublishedVersions = append(status.PublishedVersions, &svcapitypes.FunctionPublishedVersion{
+			CodeSHA256:  resp.CodeSha256,
+			PublishedAt: &now,
+			RevisionID:  latest.ko.Status.RevisionID,
+			Version:     resp.Version,
+		})
+		if len(status.PublishedVersions) > maxPublishedVersions {
+			status.PublishedVersions = status.PublishedVersions[len(status.PublishedVersions)-maxPublishedVersions:]
+		}
+		rlog.Info("published function version", "version", *resp.Version)
+	}
+
+	if status.AliasVersion != nil && *status.AliasVersion == *resp.Version {
+		return status, nil
+	}
+	if status.AliasVersion == nil {
+		_, err = rm.sdkapi.CreateAlias(ctx, &svcsdk.CreateAliasInput{
+			FunctionName:    desired.ko.Spec.Name,
+			Name:            desired.ko.Spec.AutoPublishAlias,
+			FunctionVersion: resp.Version,
+		})
+		rm.metrics.RecordAPICall("CREATE", "CreateAlias", err)
+	} else {
+		_, err = rm.sdkapi.UpdateAlias(ctx, &svcsdk.UpdateAliasInput{
+			FunctionName:    desired.ko.Spec.Name,
+			Name:            desired.ko.Spec.AutoPublishAlias,
+			FunctionVersion: resp.Version,
+		})
+		rm.metrics.RecordAPICall("UPDATE", "UpdateAlias", err)
+	}
+	if err != nil {
+		return status, err
+	}
+	status.AliasVersion = resp.Version
+	rlog.Info("moved auto-published alias", "alias", *desired.ko.Spec.AutoPublishAlias, "version", *resp.Version)
+	return status, nil
+}
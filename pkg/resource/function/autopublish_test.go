@@ -0,0 +1,78 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package function
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/lambda"
+
+	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+)
+
+// aliasCountingClient answers every request as not found and counts the
+// alias lookups.
+type aliasCountingClient struct {
+	notFoundClient
+	aliasLookups int
+}
+
+func (c *aliasCountingClient) Do(req *http.Request) (*http.Response, error) {
+	if strings.Contains(req.URL.Path, "/aliases/") {
+		c.aliasLookups++
+	}
+	return c.notFoundClient.Do(req)
+}
+
+func Test_setResourceAdditionalFields_autoPublishAlias(t *testing.T) {
+	client := &aliasCountingClient{}
+	rm := &resourceManager{
+		metrics: ackmetrics.NewMetrics("lambda"),
+		sdkapi: svcsdk.New(svcsdk.Options{
+			Region:           "us-west-2",
+			Credentials:      aws.AnonymousCredentials{},
+			HTTPClient:       client,
+			RetryMaxAttempts: 1,
+		}),
+	}
+	ko := &svcapitypes.Function{
+		Spec: svcapitypes.FunctionSpec{Name: aws.String("my-function")},
+		Status: svcapitypes.FunctionStatus{
+			AutoPublish: &svcapitypes.FunctionAutoPublishStatus{AliasVersion: aws.String("1")},
+		},
+	}
+
+	if err := rm.setResourceAdditionalFields(context.Background(), ko); err != nil {
+		t.Fatal(err)
+	}
+	if client.aliasLookups != 0 {
+		t.Errorf("expected no alias lookup without AutoPublishAlias, got %d", client.aliasLookups)
+	}
+	if ko.Status.AutoPublish != nil {
+		t.Errorf("expected the auto-publish status to be cleared, got %v", ko.Status.AutoPublish)
+	}
+
+	ko.Spec.AutoPublishAlias = aws.String("live")
+	if err := rm.setResourceAdditionalFields(context.Background(), ko); err != nil {
+		t.Fatal(err)
+	}
+	if client.aliasLookups != 1 {
+		t.Errorf("expected 1 alias lookup with AutoPublishAlias, got %d", client.aliasLookups)
+	}
+}
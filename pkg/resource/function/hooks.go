@@ -22,6 +22,7 @@ import (
 	"time"
 
 	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
 	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
 	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
 	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
@@ -29,6 +30,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	svcsdk "github.com/aws/aws-sdk-go-v2/service/lambda"
 	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var (
@@ -37,6 +40,8 @@ var (
 	ErrSourceImageDoesNotExist   = errors.New("source image does not exist")
 	ErrCannotSetFunctionCSC      = errors.New("cannot set function code signing config when package type is Image")
 	ErrCannotModifyTenancyConfig = errors.New("tenancy config cannot be modified after function creation")
+	ErrCodeStorageExceeded       = errors.New("code storage limit exceeded, pruned function versions outside of the retention policy")
+	ErrVersionIndexNotSetUp      = errors.New("the index of the Version resources isn't set up, cannot look up the managed versions")
 )
 
 var (
@@ -74,6 +79,33 @@ func isFunctionDeleting(r *resource) bool {
 	return state == string(svcapitypes.State_Deleting)
 }
 
+// setCondition sets a condition of the supplied type on the supplied Lambda
+// Function.
+func setCondition(
+	ko *svcapitypes.Function,
+	conditionType ackv1alpha1.ConditionType,
+	conditionStatus corev1.ConditionStatus,
+	message string,
+) {
+	now := metav1.Now()
+	for _, cond := range ko.Status.Conditions {
+		if cond.Type == conditionType {
+			if cond.Status != conditionStatus {
+				cond.LastTransitionTime = &now
+			}
+			cond.Status = conditionStatus
+			cond.Message = &message
+			return
+		}
+	}
+	ko.Status.Conditions = append(ko.Status.Conditions, &ackv1alpha1.Condition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		LastTransitionTime: &now,
+		Message:            &message,
+	})
+}
+
 // customUpdateFunction patches each of the resource properties in the backend AWS
 // service API and returns a new resource with updated fields.
 func (rm *resourceManager) customUpdateFunction(
@@ -93,6 +125,20 @@ func (rm *resourceManager) customUpdateFunction(
 		return updatedStatusResource, requeueWaitWhilePending
 	}
 
+	if delta.DifferentAt("Spec.RollbackOnFailure") {
+		var rollbackStatus *svcapitypes.FunctionRollbackStatus
+		rollbackStatus, err = rm.rollbackFailedUpdate(ctx, desired, latest)
+		if err != nil {
+			return updatedStatusResource, err
+		}
+		desired.ko.Status.Rollback = rollbackStatus
+		readOneLatest, err := rm.ReadOne(ctx, desired)
+		if err != nil {
+			return updatedStatusResource, err
+		}
+		return rm.concreteResource(readOneLatest), nil
+	}
+
 	if delta.DifferentAt("Spec.Tags") {
 		err = rm.updateFunctionTags(ctx, latest, desired)
 		if err != nil {
@@ -125,17 +171,71 @@ func (rm *resourceManager) customUpdateFunction(
 	if delta.DifferentAt("Spec.TenancyConfig") {
 		return updatedStatusResource, ackerr.NewTerminalError(ErrCannotModifyTenancyConfig)
 	}
+	if delta.DifferentAt("Spec.VersionRetention") {
+		// Failing to prune versions doesn't prevent the function from being
+		// synced, the versions left are retried on the next reconciliation.
+		if err := rm.pruneVersions(ctx, *desired.ko.Spec.Name, latest.ko.Status.PrunableVersions); err != nil {
+			rlog.Info("failed to prune function versions outside of the retention policy", "error", err.Error())
+		}
+	}
+
+	// While $LATEST is rolled back to a published version, Spec.Code and the
+	// function configuration are not applied.
+	if desired.ko.Spec.RestoreFromVersion != nil {
+		if delta.DifferentAt("Spec.RestoreFromVersion") {
+			var restoreStatus *svcapitypes.FunctionRestoreStatus
+			restoreStatus, err = rm.restoreFromVersion(ctx, desired, latest)
+			if restoreStatus != nil {
+				desired.ko.Status.RestoredVersion = restoreStatus
+				updatedStatusResource.ko.Status.RestoredVersion = restoreStatus
+			}
+			if err != nil {
+				return updatedStatusResource, err
+			}
+		}
+		readOneLatest, err := rm.ReadOne(ctx, desired)
+		if err != nil {
+			return updatedStatusResource, err
+		}
+		if restorePending(desired.ko.Spec.RestoreFromVersion, desired.ko.Status.RestoredVersion) {
+			return rm.concreteResource(readOneLatest), requeueWaitWhileRestoring
+		}
+		return rm.concreteResource(readOneLatest), nil
+	}
+
+	// The change whose update failed and was rolled back isn't applied again
+	// until the spec changes.
+	if isFailedGeneration(desired.ko) {
+		readOneLatest, err := rm.ReadOne(ctx, desired)
+		if err != nil {
+			return updatedStatusResource, err
+		}
+		return rm.concreteResource(readOneLatest), nil
+	}
 
 	// Only try to update Spec.Code or Spec.Configuration at once. It is
 	// not correct to sequentially call UpdateFunctionConfiguration and
 	// UpdateFunctionCode because both of them can put the function in a
 	// Pending state.
+	updated := true
 	switch {
 	case delta.DifferentAt("Spec.Code.ImageURI") || delta.DifferentAt("Spec.Code.SHA256") || delta.DifferentAt("Spec.Architectures"):
 		err = rm.updateFunctionCode(ctx, desired, delta, latest)
 		if err != nil {
 			if strings.Contains(err.Error(), "Provide a valid source image.") {
 				return updatedStatusResource, requeueWaitWhileSourceImageDoesNotExist
+			} else if isCodeStorageExceeded(err) && desired.ko.Spec.VersionRetention != nil {
+				// Free code storage by pruning the versions outside of the
+				// retention policy, then retry the update.
+				var prunable []string
+				prunable, err = rm.prunableVersions(ctx, desired.ko)
+				if err == nil && len(prunable) > 0 {
+					err = rm.pruneVersions(ctx, *desired.ko.Spec.Name, aws.StringSlice(prunable))
+				}
+				if err != nil {
+					return updatedStatusResource, err
+				}
+				return updatedStatusResource, requeueAfterPruningVersions(ErrCodeStorageExceeded)
 			} else {
 				return updatedStatusResource, err
 			}
@@ -146,11 +246,56 @@ func (rm *resourceManager) customUpdateFunction(
 		"Spec.ReservedConcurrentExecutions",
 		"Spec.FunctionEventInvokeConfig",
 		"Spec.CodeSigningConfigARN",
-		"Spec.TenancyConfig"):
+		"Spec.TenancyConfig",
+		"Spec.VersionRetention",
+		"Spec.RestoreFromVersion",
+		"Spec.AutoPublishAlias",
+		"Spec.RollbackOnFailure",
+		"Spec.SmokeTest"):
 		err = rm.updateFunctionConfiguration(ctx, desired, delta)
 		if err != nil {
 			return updatedStatusResource, err
 		}
+	default:
+		updated = false
+	}
+
+	// A new version is published once the code or configuration update
+	// finished.
+	if updated && desired.ko.Spec.AutoPublishAlias != nil {
+		readOneLatest, err := rm.ReadOne(ctx, desired)
+		if err != nil {
+			return updatedStatusResource, err
+		}
+		return rm.concreteResource(readOneLatest), requeueWaitWhileUpdateInProgress
+	}
+	if delta.DifferentAt("Spec.SmokeTest") {
+		if isFunctionUpdateInProgress(latest) {
+			return updatedStatusResource, requeueWaitWhileUpdateInProgress
+		}
+		var smokeTestResult *svcapitypes.FunctionSmokeTestStatus
+		smokeTestResult, err = rm.runSmokeTest(ctx, desired, latest)
+		if err != nil {
+			return updatedStatusResource, err
+		}
+		desired.ko.Status.SmokeTestResult = smokeTestResult
+		latest.ko.Status.SmokeTestResult = smokeTestResult
+		updatedStatusResource.ko.Status.SmokeTestResult = smokeTestResult
+	}
+	if desired.ko.Spec.AutoPublishAlias != nil &&
+		(delta.DifferentAt("Spec.AutoPublishAlias") || autoPublishPending(desired.ko.Spec.AutoPublishAlias, latest)) {
+		if isFunctionUpdateInProgress(latest) {
+			return updatedStatusResource, requeueWaitWhileUpdateInProgress
+		}
+		var autoPublishStatus *svcapitypes.FunctionAutoPublishStatus
+		autoPublishStatus, err = rm.autoPublish(ctx, desired, latest)
+		if autoPublishStatus != nil {
+			desired.ko.Status.AutoPublish = autoPublishStatus
+			updatedStatusResource.ko.Status.AutoPublish = autoPublishStatus
+		}
+		if err != nil {
+			return updatedStatusResource, err
+		}
 	}
 
 	readOneLatest, err := rm.ReadOne(ctx, desired)
@@ -571,6 +716,28 @@ func customPreCompare(
 			}
 		}
 	}
+
+	if rollbackPending(a, b) {
+		delta.Add("Spec.RollbackOnFailure", a.ko.Spec.RollbackOnFailure, b.ko.Status.LastUpdateStatus)
+	}
+
+	if smokeTestPending(a, b) {
+		delta.Add("Spec.SmokeTest", a.ko.Spec.SmokeTest, b.ko.Status.SmokeTestResult)
+	}
+
+	if autoPublishPending(a.ko.Spec.AutoPublishAlias, b) {
+		delta.Add("Spec.AutoPublishAlias", a.ko.Spec.AutoPublishAlias, b.ko.Status.AutoPublish)
+	}
+
+	if restorePending(a.ko.Spec.RestoreFromVersion, b.ko.Status.RestoredVersion) {
+		delta.Add("Spec.RestoreFromVersion", a.ko.Spec.RestoreFromVersion, b.ko.Status.RestoredVersion)
+	}
+
+	// The retention policy is out of sync as long as there are versions left
+	// to prune.
+	if a.ko.Spec.VersionRetention != nil && len(b.ko.Status.PrunableVersions) > 0 {
+		delta.Add("Spec.VersionRetention", a.ko.Spec.VersionRetention, b.ko.Status.PrunableVersions)
+	}
 }
 
 // updateFunctionConcurrency calls `PutFunctionConcurrency` to update the fields
@@ -902,5 +1069,30 @@ func (rm *resourceManager) setResourceAdditionalFields(
 		return ackerr.NewTerminalError(ErrCannotSetFunctionCSC)
 	}
 
+	// To snapshot the code and configuration of the last successful update
+	setLastKnownGood(ko)
+	setRolledBackCondition(ko)
+	setSmokeTestPassedCondition(ko)
+
+	// To set the version the auto-published alias points to
+	if ko.Spec.AutoPublishAlias != nil {
+		err = rm.setAutoPublishAliasVersion(ctx, ko)
+		if err != nil {
+			return err
+		}
+	} else {
+		ko.Status.AutoPublish = nil
+	}
+
+	// To set the versions to prune according to the retention policy
+	err = setManagedVersions(ctx, versionLister, ko)
+	if err != nil {
+		return err
+	}
+	err = rm.setPrunableVersions(ctx, ko)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -71,6 +71,10 @@ func (rm *resourceManager) ClearResolvedReferences(res acktypes.AWSResource) ack
 		ko.Spec.KMSKeyARN = nil
 	}
 
+	if ko.Spec.RestoreFromVersionRef != nil {
+		ko.Spec.RestoreFromVersion = nil
+	}
+
 	if ko.Spec.RoleRef != nil {
 		ko.Spec.Role = nil
 	}
@@ -87,6 +91,12 @@ func (rm *resourceManager) ClearResolvedReferences(res acktypes.AWSResource) ack
 		}
 	}
 
+	if ko.Spec.SmokeTest != nil {
+		if ko.Spec.SmokeTest.PayloadFrom != nil {
+			ko.Spec.SmokeTest.Payload = nil
+		}
+	}
+
 	return &resource{ko}
 }
 
@@ -124,6 +134,12 @@ func (rm *resourceManager) ResolveReferences(
 		resourceHasReferences = resourceHasReferences || fieldHasReferences
 	}
 
+	if fieldHasReferences, err := rm.resolveReferenceForRestoreFromVersion(ctx, apiReader, ko); err != nil {
+		return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
+	} else {
+		resourceHasReferences = resourceHasReferences || fieldHasReferences
+	}
+
 	if fieldHasReferences, err := rm.resolveReferenceForRole(ctx, apiReader, ko); err != nil {
 		return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
 	} else {
@@ -141,6 +157,11 @@ func (rm *resourceManager) ResolveReferences(
 	} else {
 		resourceHasReferences = resourceHasReferences || fieldHasReferences
 	}
+	if err == nil {
+		if err := rm.resolveSmokeTestPayload(ctx, apiReader, ko); err != nil {
+			return &resource{ko}, resourceHasReferences, err
+		}
+	}
 
 	return &resource{ko}, resourceHasReferences, err
 }
@@ -163,6 +184,10 @@ func validateReferenceFields(ko *svcapitypes.Function) error {
 		return ackerr.ResourceReferenceAndIDNotSupportedFor("KMSKeyARN", "KMSKeyRef")
 	}
 
+	if ko.Spec.RestoreFromVersionRef != nil && ko.Spec.RestoreFromVersion != nil {
+		return ackerr.ResourceReferenceAndIDNotSupportedFor("RestoreFromVersion", "RestoreFromVersionRef")
+	}
+
 	if ko.Spec.RoleRef != nil && ko.Spec.Role != nil {
 		return ackerr.ResourceReferenceAndIDNotSupportedFor("Role", "RoleRef")
 	}
@@ -459,6 +484,97 @@ func getReferencedResourceState_Key(
 	return nil
 }
 
+// resolveReferenceForRestoreFromVersion reads the resource referenced
+// from RestoreFromVersionRef field and sets the RestoreFromVersion
+// from referenced resource. Returns a boolean indicating whether a reference
+// contains references, or an error
+func (rm *resourceManager) resolveReferenceForRestoreFromVersion(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.Function,
+) (hasReferences bool, err error) {
+	if ko.Spec.RestoreFromVersionRef != nil && ko.Spec.RestoreFromVersionRef.From != nil {
+		hasReferences = true
+		arr := ko.Spec.RestoreFromVersionRef.From
+		if arr.Name == nil || *arr.Name == "" {
+			return hasReferences, fmt.Errorf("provided resource reference is nil or empty: RestoreFromVersionRef")
+		}
+		namespace, err := ackrt.ResolveCrossNamespaceReference(
+			ctx,
+			rm.cfg.EnableCrossNamespace,
+			&ko.Status.Conditions,
+			ackrt.CrossNamespaceRefKindResource,
+			ko.ObjectMeta.GetNamespace(),
+			arr.Namespace,
+			*arr.Name,
+		)
+		if err != nil {
+			return hasReferences, err
+		}
+		obj := &svcapitypes.Version{}
+		if err := getReferencedResourceState_Version(ctx, apiReader, obj, *arr.Name, namespace); err != nil {
+			return hasReferences, err
+		}
+		ko.Spec.RestoreFromVersion = (*string)(obj.Status.Version)
+	}
+
+	return hasReferences, nil
+}
+
+// getReferencedResourceState_Version looks up whether a referenced resource
+// exists and is in a ACK.ResourceSynced=True state. If the referenced resource does exist and is
+// in a Synced state, returns nil, otherwise returns `ackerr.ResourceReferenceTerminalFor` or
+// `ResourceReferenceNotSyncedFor` depending on if the resource is in a Terminal state.
+func getReferencedResourceState_Version(
+	ctx context.Context,
+	apiReader client.Reader,
+	obj *svcapitypes.Version,
+	name string, // the Kubernetes name of the referenced resource
+	namespace string, // the Kubernetes namespace of the referenced resource
+) error {
+	namespacedName := types.NamespacedName{
+		Namespace: namespace,
+		Name:      name,
+	}
+	err := apiReader.Get(ctx, namespacedName, obj)
+	if err != nil {
+		return err
+	}
+	var refResourceTerminal bool
+	for _, cond := range obj.Status.Conditions {
+		if cond.Type == ackv1alpha1.ConditionTypeTerminal &&
+			cond.Status == corev1.ConditionTrue {
+			return ackerr.ResourceReferenceTerminalFor(
+				"Version",
+				namespace, name)
+		}
+	}
+	if refResourceTerminal {
+		return ackerr.ResourceReferenceTerminalFor(
+			"Version",
+			namespace, name)
+	}
+	var refResourceSynced bool
+	for _, cond := range obj.Status.Conditions {
+		if cond.Type == ackv1alpha1.ConditionTypeResourceSynced &&
+			cond.Status == corev1.ConditionTrue {
+			refResourceSynced = true
+		}
+	}
+	if !refResourceSynced {
+		return ackerr.ResourceReferenceNotSyncedFor(
+			"Version",
+			namespace, name)
+	}
+	if obj.Status.Version == nil {
+		return ackerr.ResourceReferenceMissingTargetFieldFor(
+			"Version",
+			namespace, name,
+			"Status.Version")
+	}
+	return nil
+}
+
 // resolveReferenceForRole reads the resource referenced
 // from RoleRef field and sets the Role
 // from referenced resource. Returns a boolean indicating whether a reference
@@ -0,0 +1,282 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package function
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/lambda"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+)
+
+var (
+	ErrRestoredCodeTooLarge   = errors.New("the code of the version is larger than the 50 MB ZipFile limit, it can't be restored")
+	ErrRestoringConfiguration = errors.New("function code restored, waiting for the update to finish to restore the configuration")
+)
+
+// restoredConfigurationFields are the configuration fields restored from a
+// published version or from the last known-good snapshot.
+var restoredConfigurationFields = []string{
+	"Spec.DeadLetterConfig",
+	"Spec.Description",
+	"Spec.Environment",
+	"Spec.EphemeralStorage",
+	"Spec.Handler",
+	"Spec.KMSKeyARN",
+	"Spec.Layers",
+	"Spec.MemorySize",
+	"Spec.Role",
+	"Spec.Runtime",
+	"Spec.Timeout",
+	"Spec.TracingConfig",
+	"Spec.VPCConfig",
+}
+
+// maxZipFileSize is the largest .zip file archive Lambda accepts in the
+// ZipFile field of UpdateFunctionCode.
+const maxZipFileSize = 50 * 1024 * 1024
+
+// codeDownloadTimeout bounds the download of the code of a published version.
+const codeDownloadTimeout = 2 * time.Minute
+
+// codeDownloadClient downloads the code of published versions from the
+// presigned URL GetFunction returns.
+var codeDownloadClient = &http.Client{Timeout: codeDownloadTimeout}
+
+var (
+	requeueWaitWhileRestoring = ackrequeue.NeededAfter(
+		ErrRestoringConfiguration,
+		5*time.Second,
+	)
+)
+
+// restorePending returns true if $LATEST wasn't rolled back to the supplied
+// version yet.
+func restorePending(
+	version *string,
+	status *svcapitypes.FunctionRestoreStatus,
+) bool {
+	if version == nil {
+		return false
+	}
+	return status == nil || status.Version == nil || *status.Version != *version ||
+		status.ConfigurationRestored == nil || !*status.ConfigurationRestored
+}
+
+// restoreFromVersion rolls $LATEST back to the code and configuration of the
+// desired published version. The code is restored first. As both
+// UpdateFunctionCode and UpdateFunctionConfiguration put the function in a
+// Pending state, the configuration is restored on a later reconciliation once
+// the code update finished. It returns the restore status to record.
+func (rm *resourceManager) restoreFromVersion(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+) (status *svcapitypes.FunctionRestoreStatus, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.restoreFromVersion")
+	defer exit(err)
+
+	version := *desired.ko.Spec.RestoreFromVersion
+	var resp *svcsdk.GetFunctionOutput
+	resp, err = rm.sdkapi.GetFunction(ctx, &svcsdk.GetFunctionInput{
+		FunctionName: desired.ko.Spec.Name,
+		Qualifier:    aws.String(version),
+	})
+	rm.metrics.RecordAPICall("READ_ONE", "GetFunction", err)
+	if err != nil {
+		return nil, err
+	}
+	restored := rm.concreteResource(desired.DeepCopy())
+	setRestoredConfiguration(restored.ko, resp.Configuration)
+
+	observed := latest.ko.Status.RestoredVersion
+	if observed == nil || observed.Version == nil || *observed.Version != version {
+		delta := ackcompare.NewDelta()
+		restored.ko.Spec.Code, err = rm.restoredCode(ctx, resp)
+		if err != nil {
+			return nil, err
+		}
+		if restored.ko.Spec.Code.ImageURI != nil {
+			delta.Add("Spec.Code.ImageURI", nil, restored.ko.Spec.Code.ImageURI)
+		} else {
+			delta.Add("Spec.Code.SHA256", latest.ko.Status.CodeSHA256, restored.ko.Spec.Code.SHA256)
+		}
+		if err = rm.updateFunctionCode(ctx, restored, delta, latest); err != nil {
+			return nil, err
+		}
+		rlog.Info("restored function code from version", "version", version)
+		return &svcapitypes.FunctionRestoreStatus{
+			CodeSHA256:            resp.Configuration.CodeSha256,
+			ConfigurationRestored: aws.Bool(false),
+			Version:               aws.String(version),
+		}, nil
+	}
+
+	if latest.ko.Status.LastUpdateStatus != nil &&
+		*latest.ko.Status.LastUpdateStatus == string(svcapitypes.LastUpdateStatus_InProgress) {
+		return observed, requeueWaitWhileRestoring
+	}
+	delta := ackcompare.NewDelta()
+	for _, path := range restoredConfigurationFields {
+		delta.Add(path, nil, nil)
+	}
+	if err = rm.updateFunctionConfiguration(ctx, restored, delta); err != nil {
+		return observed, err
+	}
+	rlog.Info("restored function configuration from version", "version", version)
+	now := metav1.Now()
+	return &svcapitypes.FunctionRestoreStatus{
+		CodeSHA256:            observed.CodeSHA256,
+		ConfigurationRestored: aws.Bool(true),
+		RestoredAt:            &now,
+		Version:               aws.String(version),
+	}, nil
+}
+
+// restoredCode returns the code of the published version. Container images
+// are restored from their digest, .zip file archives are downloaded from the
+// presigned URL GetFunction returns. Archives larger than the ZipFile limit
+// can't be restored and return a terminal error.
+func (rm *resourceManager) restoredCode(
+	ctx context.Context,
+	resp *svcsdk.GetFunctionOutput,
+) (*svcapitypes.FunctionCode, error) {
+	code := &svcapitypes.FunctionCode{
+		SHA256: resp.Configuration.CodeSha256,
+	}
+	if resp.Code == nil {
+		return nil, fmt.Errorf("no code location returned for version %s", aws.ToString(resp.Configuration.Version))
+	}
+	if resp.Code.ResolvedImageUri != nil {
+		code.ImageURI = resp.Code.ResolvedImageUri
+		return code, nil
+	}
+	if resp.Code.ImageUri != nil {
+		code.ImageURI = resp.Code.ImageUri
+		return code, nil
+	}
+	if resp.Code.Location == nil {
+		return nil, fmt.Errorf("no code location returned for version %s", aws.ToString(resp.Configuration.Version))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, codeDownloadTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, *resp.Code.Location, nil)
+	if err != nil {
+		return nil, err
+	}
+	httpResp, err := codeDownloadClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading the code of version %s: %s", aws.ToString(resp.Configuration.Version), httpResp.Status)
+	}
+	tooLarge := ackerr.NewTerminalError(fmt.Errorf("%w: version %s", ErrRestoredCodeTooLarge, aws.ToString(resp.Configuration.Version)))
+	if httpResp.ContentLength > maxZipFileSize {
+		return nil, tooLarge
+	}
+	// Read one byte past the limit to tell a package of exactly the maximum
+	// size from a larger one.
+	code.ZipFile, err = io.ReadAll(io.LimitReader(httpResp.Body, maxZipFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(code.ZipFile) > maxZipFileSize {
+		code.ZipFile = nil
+		return nil, tooLarge
+	}
+	return code, nil
+}
+
+// setRestoredConfiguration sets the configuration fields of the supplied
+// Function to the configuration of a published version.
+func setRestoredConfiguration(
+	ko *svcapitypes.Function,
+	config *svcsdktypes.FunctionConfiguration,
+) {
+	if config == nil {
+		return
+	}
+	ko.Spec.Architectures = nil
+	for _, architecture := range config.Architectures {
+		ko.Spec.Architectures = append(ko.Spec.Architectures, aws.String(string(architecture)))
+	}
+	ko.Spec.DeadLetterConfig = nil
+	if config.DeadLetterConfig != nil {
+		ko.Spec.DeadLetterConfig = &svcapitypes.DeadLetterConfig{
+			TargetARN: config.DeadLetterConfig.TargetArn,
+		}
+	}
+	ko.Spec.Description = config.Description
+	ko.Spec.Environment = nil
+	if config.Environment != nil {
+		ko.Spec.Environment = &svcapitypes.Environment{
+			Variables: aws.StringMap(config.Environment.Variables),
+		}
+	}
+	ko.Spec.EphemeralStorage = nil
+	if config.EphemeralStorage != nil && config.EphemeralStorage.Size != nil {
+		ko.Spec.EphemeralStorage = &svcapitypes.EphemeralStorage{
+			Size: aws.Int64(int64(*config.EphemeralStorage.Size)),
+		}
+	}
+	ko.Spec.Handler = config.Handler
+	ko.Spec.KMSKeyARN = config.KMSKeyArn
+	ko.Spec.Layers = nil
+	for _, layer := range config.Layers {
+		ko.Spec.Layers = append(ko.Spec.Layers, layer.Arn)
+	}
+	ko.Spec.MemorySize = nil
+	if config.MemorySize != nil {
+		ko.Spec.MemorySize = aws.Int64(int64(*config.MemorySize))
+	}
+	ko.Spec.Role = config.Role
+	ko.Spec.Runtime = nil
+	if config.Runtime != "" {
+		ko.Spec.Runtime = aws.String(string(config.Runtime))
+	}
+	ko.Spec.Timeout = nil
+	if config.Timeout != nil {
+		ko.Spec.Timeout = aws.Int64(int64(*config.Timeout))
+	}
+	ko.Spec.TracingConfig = nil
+	if config.TracingConfig != nil && config.TracingConfig.Mode != "" {
+		ko.Spec.TracingConfig = &svcapitypes.TracingConfig{
+			Mode: aws.String(string(config.TracingConfig.Mode)),
+		}
+	}
+	ko.Spec.VPCConfig = nil
+	if config.VpcConfig != nil {
+		ko.Spec.VPCConfig = &svcapitypes.VPCConfig{
+			SecurityGroupIDs: aws.StringSlice(config.VpcConfig.SecurityGroupIds),
+			SubnetIDs:        aws.StringSlice(config.VpcConfig.SubnetIds),
+		}
+	}
+}
@@ -0,0 +1,383 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package function
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/lambda"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+)
+
+// requeueAfterPruningVersions retries the update once unreferenced versions
+// were deleted to free code storage.
+func requeueAfterPruningVersions(err error) *ackrequeue.RequeueNeededAfter {
+	return ackrequeue.NeededAfter(err, 5*time.Second)
+}
+
+// isCodeStorageExceeded returns true if the supplied error is a
+// CodeStorageExceededException.
+func isCodeStorageExceeded(err error) bool {
+	var codeStorageExceeded *svcsdktypes.CodeStorageExceededException
+	return errors.As(err, &codeStorageExceeded)
+}
+
+// versionFunctionIndex indexes the Version resources by the function they
+// publish versions of, as returned by versionFunctionKey.
+const versionFunctionIndex = "spec.functionKey"
+
+// versionLister lists the Version resources from the cache of the controller
+// manager. It is set by SetupVersionIndex.
+var versionLister client.Reader
+
+// SetupVersionIndex indexes the Version resources cached by the controller
+// manager by the function they publish versions of, so that the versions
+// managed by Version resources are looked up without listing every Version.
+// It must be called before the manager is started.
+func SetupVersionIndex(ctx context.Context, mgr ctrlrt.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(
+		ctx,
+		&svcapitypes.Version{},
+		versionFunctionIndex,
+		indexVersionFunction,
+	)
+	if err != nil {
+		return err
+	}
+	versionLister = mgr.GetClient()
+	return nil
+}
+
+// indexVersionFunction returns the index values of a Version resource for
+// versionFunctionIndex.
+func indexVersionFunction(obj client.Object) []string {
+	if key := versionFunctionKey(obj.(*svcapitypes.Version)); key != "" {
+		return []string{key}
+	}
+	return nil
+}
+
+// setManagedVersions sets the published versions of the function that a
+// Version resource manages, so that the retention policy never prunes them.
+func setManagedVersions(
+	ctx context.Context,
+	lister client.Reader,
+	ko *svcapitypes.Function,
+) error {
+	ko.Status.ManagedVersions = nil
+	if ko.Spec.VersionRetention == nil || ko.Spec.Name == nil {
+		return nil
+	}
+	if lister == nil {
+		return ErrVersionIndexNotSetUp
+	}
+	managed := []string{}
+	for _, key := range functionKeys(ko) {
+		list := &svcapitypes.VersionList{}
+		if err := lister.List(ctx, list, client.MatchingFields{versionFunctionIndex: key}); err != nil {
+			return err
+		}
+		for i := range list.Items {
+			if version := list.Items[i].Status.Version; version != nil {
+				managed = append(managed, *version)
+			}
+		}
+	}
+	sort.Strings(managed)
+	if len(managed) > 0 {
+		ko.Status.ManagedVersions = aws.StringSlice(managed)
+	}
+	return nil
+}
+
+// versionFunctionKey returns the key of the function the supplied Version
+// resource publishes versions of: the namespaced name of the referenced
+// Function resource, or the name of the function.
+func versionFunctionKey(version *svcapitypes.Version) string {
+	if ref := version.Spec.FunctionRef; ref != nil && ref.From != nil && ref.From.Name != nil {
+		namespace := version.Namespace
+		if ref.From.Namespace != nil && *ref.From.Namespace != "" {
+			namespace = *ref.From.Namespace
+		}
+		return "ref:" + namespace + "/" + *ref.From.Name
+	}
+	if version.Spec.FunctionName == nil {
+		return ""
+	}
+	// Function ARNs and partial ARNs end with function:<name>.
+	name := *version.Spec.FunctionName
+	if i := strings.LastIndex(name, "function:"); i >= 0 {
+		name = name[i+len("function:"):]
+	}
+	return "name:" + name
+}
+
+// functionKeys returns the keys under which the Version resources publishing
+// versions of the supplied function are indexed.
+func functionKeys(ko *svcapitypes.Function) []string {
+	return []string{
+		"ref:" + ko.Namespace + "/" + ko.Name,
+		"name:" + *ko.Spec.Name,
+	}
+}
+
+// setPrunableVersions sets the published versions of the function that fall
+// outside of its retention policy and are not referenced anywhere.
+func (rm *resourceManager) setPrunableVersions(
+	ctx context.Context,
+	ko *svcapitypes.Function,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.setPrunableVersions")
+	defer exit(err)
+
+	ko.Status.PrunableVersions = nil
+	if ko.Spec.VersionRetention == nil || ko.Spec.VersionRetention.KeepLatest == nil {
+		return nil
+	}
+	var prunable []string
+	prunable, err = rm.prunableVersions(ctx, ko)
+	if err != nil {
+		return err
+	}
+	if len(prunable) > 0 {
+		ko.Status.PrunableVersions = aws.StringSlice(prunable)
+	}
+	return nil
+}
+
+// prunableVersions returns the published versions of the function beyond the
+// keepLatest most recent ones that no Version resource manages, and that no
+// alias, event source mapping or function URL configuration references.
+func (rm *resourceManager) prunableVersions(
+	ctx context.Context,
+	ko *svcapitypes.Function,
+) ([]string, error) {
+	functionName := *ko.Spec.Name
+	candidates, err := rm.retentionCandidates(ctx, functionName, *ko.Spec.VersionRetention.KeepLatest, ko.Status.ManagedVersions)
+	if err != nil || len(candidates) == 0 {
+		return nil, err
+	}
+	return rm.unreferencedVersions(ctx, functionName, candidates)
+}
+
+// retentionCandidates returns the published versions of the function beyond
+// the keepLatest most recent ones, except the managed ones.
+func (rm *resourceManager) retentionCandidates(
+	ctx context.Context,
+	functionName string,
+	keepLatest int64,
+	managed []*string,
+) ([]string, error) {
+	versions, err := rm.listPublishedVersions(ctx, functionName)
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(versions)) <= keepLatest {
+		return nil, nil
+	}
+	excluded := map[string]bool{}
+	for _, version := range managed {
+		if version != nil {
+			excluded[*version] = true
+		}
+	}
+	candidates := []string{}
+	for _, version := range versions[keepLatest:] {
+		if !excluded[version] {
+			candidates = append(candidates, version)
+		}
+	}
+	return candidates, nil
+}
+
+// unreferencedVersions returns the candidate versions of the function that no
+// alias, event source mapping or function URL configuration references.
+func (rm *resourceManager) unreferencedVersions(
+	ctx context.Context,
+	functionName string,
+	candidates []string,
+) ([]string, error) {
+	referenced, err := rm.referencedVersions(ctx, functionName)
+	if err != nil {
+		return nil, err
+	}
+	prunable := []string{}
+	for _, version := range candidates {
+		if referenced[version] {
+			continue
+		}
+		// Event source mappings are attached to the qualified function, so
+		// they have to be looked up for each version.
+		var mappings *svcsdk.ListEventSourceMappingsOutput
+		mappings, err = rm.sdkapi.ListEventSourceMappings(ctx, &svcsdk.ListEventSourceMappingsInput{
+			FunctionName: aws.String(functionName + ":" + version),
+			MaxItems:     aws.Int32(1),
+		})
+		rm.metrics.RecordAPICall("READ_MANY", "ListEventSourceMappings", err)
+		if err != nil {
+			return nil, err
+		}
+		if len(mappings.EventSourceMappings) > 0 {
+			continue
+		}
+		prunable = append(prunable, version)
+	}
+	return prunable, nil
+}
+
+// listPublishedVersions returns the published versions of the function, the
+// most recent first.
+func (rm *resourceManager) listPublishedVersions(
+	ctx context.Context,
+	functionName string,
+) ([]string, error) {
+	var err error
+	versions := []string{}
+	input := &svcsdk.ListVersionsByFunctionInput{
+		FunctionName: aws.String(functionName),
+	}
+	for {
+		var resp *svcsdk.ListVersionsByFunctionOutput
+		resp, err = rm.sdkapi.ListVersionsByFunction(ctx, input)
+		rm.metrics.RecordAPICall("READ_MANY", "ListVersionsByFunction", err)
+		if err != nil {
+			return nil, err
+		}
+		for _, config := range resp.Versions {
+			if config.Version == nil || *config.Version == "$LATEST" {
+				continue
+			}
+			versions = append(versions, *config.Version)
+		}
+		if resp.NextMarker == nil {
+			break
+		}
+		input.Marker = resp.NextMarker
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		vi, _ := strconv.Atoi(versions[i])
+		vj, _ := strconv.Atoi(versions[j])
+		return vi > vj
+	})
+	return versions, nil
+}
+
+// referencedVersions returns the versions of the function that an alias,
+// including its routing configuration, or a function URL configuration
+// points to.
+func (rm *resourceManager) referencedVersions(
+	ctx context.Context,
+	functionName string,
+) (map[string]bool, error) {
+	var err error
+	referenced := map[string]bool{}
+
+	aliasesInput := &svcsdk.ListAliasesInput{
+		FunctionName: aws.String(functionName),
+	}
+	for {
+		var resp *svcsdk.ListAliasesOutput
+		resp, err = rm.sdkapi.ListAliases(ctx, aliasesInput)
+		rm.metrics.RecordAPICall("READ_MANY", "ListAliases", err)
+		if err != nil {
+			return nil, err
+		}
+		for _, alias := range resp.Aliases {
+			if alias.FunctionVersion != nil {
+				referenced[*alias.FunctionVersion] = true
+			}
+			if alias.RoutingConfig != nil {
+				for version := range alias.RoutingConfig.AdditionalVersionWeights {
+					referenced[version] = true
+				}
+			}
+		}
+		if resp.NextMarker == nil {
+			break
+		}
+		aliasesInput.Marker = resp.NextMarker
+	}
+
+	urlConfigsInput := &svcsdk.ListFunctionUrlConfigsInput{
+		FunctionName: aws.String(functionName),
+	}
+	for {
+		var resp *svcsdk.ListFunctionUrlConfigsOutput
+		resp, err = rm.sdkapi.ListFunctionUrlConfigs(ctx, urlConfigsInput)
+		rm.metrics.RecordAPICall("READ_MANY", "ListFunctionUrlConfigs", err)
+		if err != nil {
+			return nil, err
+		}
+		for _, config := range resp.FunctionUrlConfigs {
+			// Function URLs are attached to an alias or to $LATEST. Aliases
+			// are already covered above, only keep explicit versions.
+			if config.FunctionArn == nil {
+				continue
+			}
+			qualifier := (*config.FunctionArn)[strings.LastIndex(*config.FunctionArn, ":")+1:]
+			if _, err := strconv.Atoi(qualifier); err == nil {
+				referenced[qualifier] = true
+			}
+		}
+		if resp.NextMarker == nil {
+			break
+		}
+		urlConfigsInput.Marker = resp.NextMarker
+	}
+
+	return referenced, nil
+}
+
+// pruneVersions deletes the supplied versions of the function. Versions
+// already deleted are ignored.
+func (rm *resourceManager) pruneVersions(
+	ctx context.Context,
+	functionName string,
+	versions []*string,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.pruneVersions")
+	defer exit(err)
+
+	for _, version := range versions {
+		_, err = rm.sdkapi.DeleteFunction(ctx, &svcsdk.DeleteFunctionInput{
+			FunctionName: aws.String(functionName),
+			Qualifier:    version,
+		})
+		rm.metrics.RecordAPICall("DELETE", "DeleteFunction", err)
+		if err != nil {
+			var notFound *svcsdktypes.ResourceNotFoundException
+			if errors.As(err, &notFound) {
+				err = nil
+				continue
+			}
+			return err
+		}
+		rlog.Info("deleted function version outside of the retention policy", "version", *version)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package function
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+)
+
+func Test_versionFunctionKey(t *testing.T) {
+	function := &svcapitypes.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-function", Namespace: "apps"},
+		Spec:       svcapitypes.FunctionSpec{Name: aws.String("my-lambda")},
+	}
+	reference := func(name string, namespace *string) *ackv1alpha1.AWSResourceReferenceWrapper {
+		return &ackv1alpha1.AWSResourceReferenceWrapper{
+			From: &ackv1alpha1.AWSResourceReference{Name: aws.String(name), Namespace: namespace},
+		}
+	}
+	tests := []struct {
+		name      string
+		namespace string
+		spec      svcapitypes.VersionSpec
+		want      bool
+	}{
+		{
+			name:      "function name",
+			namespace: "other",
+			spec:      svcapitypes.VersionSpec{FunctionName: aws.String("my-lambda")},
+			want:      true,
+		},
+		{
+			name:      "function ARN",
+			namespace: "other",
+			spec:      svcapitypes.VersionSpec{FunctionName: aws.String("arn:aws:lambda:us-west-2:123456789012:function:my-lambda")},
+			want:      true,
+		},
+		{
+			name:      "other function",
+			namespace: "apps",
+			spec:      svcapitypes.VersionSpec{FunctionName: aws.String("my-lambda-2")},
+			want:      false,
+		},
+		{
+			name:      "reference in the same namespace",
+			namespace: "apps",
+			spec:      svcapitypes.VersionSpec{FunctionRef: reference("my-function", nil)},
+			want:      true,
+		},
+		{
+			name:      "reference from another namespace",
+			namespace: "other",
+			spec:      svcapitypes.VersionSpec{FunctionRef: reference("my-function", aws.String("apps"))},
+			want:      true,
+		},
+		{
+			name:      "reference to a function of another namespace",
+			namespace: "other",
+			spec:      svcapitypes.VersionSpec{FunctionRef: reference("my-function", nil)},
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version := &svcapitypes.Version{
+				ObjectMeta: metav1.ObjectMeta{Namespace: tt.namespace},
+				Spec:       tt.spec,
+			}
+			key := versionFunctionKey(version)
+			if got := slices.Contains(functionKeys(function), key); got != tt.want {
+				t.Errorf("versionFunctionKey() = %q, matches the function = %v, want %v", key, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_setManagedVersions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := svcapitypes.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	newVersion := func(name string, namespace string, spec svcapitypes.VersionSpec, version string) *svcapitypes.Version {
+		return &svcapitypes.Version{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       spec,
+			Status:     svcapitypes.VersionStatus{Version: aws.String(version)},
+		}
+	}
+	lister := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&svcapitypes.Version{}, versionFunctionIndex, indexVersionFunction).
+		WithObjects(
+			newVersion("by-name", "other", svcapitypes.VersionSpec{FunctionName: aws.String("my-lambda")}, "3"),
+			newVersion("by-reference", "apps", svcapitypes.VersionSpec{
+				FunctionRef: &ackv1alpha1.AWSResourceReferenceWrapper{
+					From: &ackv1alpha1.AWSResourceReference{Name: aws.String("my-function")},
+				},
+			}, "1"),
+			newVersion("other-function", "apps", svcapitypes.VersionSpec{FunctionName: aws.String("my-lambda-2")}, "2"),
+		).
+		Build()
+	function := &svcapitypes.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-function", Namespace: "apps"},
+		Spec: svcapitypes.FunctionSpec{
+			Name:             aws.String("my-lambda"),
+			VersionRetention: &svcapitypes.FunctionVersionRetention{KeepLatest: aws.Int64(2)},
+		},
+	}
+
+	if err := setManagedVersions(context.TODO(), lister, function); err != nil {
+		t.Fatalf("setManagedVersions() error = %v", err)
+	}
+	if got := aws.ToStringSlice(function.Status.ManagedVersions); !slices.Equal(got, []string{"1", "3"}) {
+		t.Errorf("ManagedVersions = %v, want [1 3]", got)
+	}
+
+	if err := setManagedVersions(context.TODO(), nil, function); err != ErrVersionIndexNotSetUp {
+		t.Errorf("setManagedVersions() without an index error = %v, want %v", err, ErrVersionIndexNotSetUp)
+	}
+}
@@ -0,0 +1,308 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package function
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+)
+
+// ConditionTypeRolledBack is raised on a Function whose failed update was
+// rolled back to its last known-good code and configuration.
+const ConditionTypeRolledBack ackv1alpha1.ConditionType = "RolledBack"
+
+var (
+	ErrUnknownLastKnownGoodCode = errors.New("cannot roll back the function code, the location of the last known-good code is unknown")
+)
+
+// isFunctionUpdateFailed returns true if the last update of the supplied
+// Lambda Function failed.
+func isFunctionUpdateFailed(r *resource) bool {
+	if r.ko.Status.LastUpdateStatus == nil {
+		return false
+	}
+	return *r.ko.Status.LastUpdateStatus == string(svcapitypes.LastUpdateStatus_Failed)
+}
+
+// rollbackEnabled returns true if failed updates of the supplied Lambda
+// Function are rolled back.
+func rollbackEnabled(ko *svcapitypes.Function) bool {
+	return ko.Spec.RollbackOnFailure != nil && *ko.Spec.RollbackOnFailure
+}
+
+// isFailedGeneration returns true if the update of the current generation of
+// the supplied Lambda Function failed and was rolled back.
+func isFailedGeneration(ko *svcapitypes.Function) bool {
+	return rollbackEnabled(ko) && ko.Status.Rollback != nil &&
+		ko.Status.Rollback.FailedGeneration != nil &&
+		*ko.Status.Rollback.FailedGeneration == ko.Generation
+}
+
+// rollbackPending returns true if the last update of the function failed and
+// can be rolled back to the last known-good snapshot.
+func rollbackPending(
+	desired *resource,
+	latest *resource,
+) bool {
+	if !rollbackEnabled(desired.ko) || !isFunctionUpdateFailed(latest) {
+		return false
+	}
+	status := latest.ko.Status.Rollback
+	if status == nil || status.LastKnownGood == nil {
+		return false
+	}
+	// A failed rollback isn't rolled back again.
+	return status.FailedGeneration == nil || *status.FailedGeneration != desired.ko.Generation
+}
+
+// setLastKnownGood snapshots the observed code and configuration of the
+// function when its last update succeeded.
+func setLastKnownGood(ko *svcapitypes.Function) {
+	if !rollbackEnabled(ko) {
+		ko.Status.Rollback = nil
+		return
+	}
+	if ko.Status.Rollback == nil {
+		ko.Status.Rollback = &svcapitypes.FunctionRollbackStatus{}
+	}
+	status := ko.Status.Rollback
+
+	if ko.Status.LastUpdateStatus != nil &&
+		*ko.Status.LastUpdateStatus != string(svcapitypes.LastUpdateStatus_Successful) {
+		return
+	}
+	if ko.Status.State == nil || (*ko.Status.State != string(svcapitypes.State_Active) &&
+		*ko.Status.State != string(svcapitypes.State_Inactive)) {
+		return
+	}
+
+	snapshot := &svcapitypes.FunctionSnapshot{
+		Architectures:    ko.Spec.Architectures,
+		DeadLetterConfig: ko.Spec.DeadLetterConfig,
+		Description:      ko.Spec.Description,
+		Environment:      ko.Spec.Environment,
+		EphemeralStorage: ko.Spec.EphemeralStorage,
+		Handler:          ko.Spec.Handler,
+		KMSKeyARN:        ko.Spec.KMSKeyARN,
+		Layers:           ko.Spec.Layers,
+		MemorySize:       ko.Spec.MemorySize,
+		RevisionID:       ko.Status.RevisionID,
+		Role:             ko.Spec.Role,
+		Runtime:          ko.Spec.Runtime,
+		Timeout:          ko.Spec.Timeout,
+		TracingConfig:    ko.Spec.TracingConfig,
+		VPCConfig:        ko.Spec.VPCConfig,
+	}
+	snapshot = snapshot.DeepCopy()
+	if snapshot.VPCConfig != nil {
+		snapshot.VPCConfig.SecurityGroupRefs = nil
+		snapshot.VPCConfig.SubnetRefs = nil
+	}
+
+	// The observed image URI is the deployed one, the location of a .zip
+	// file archive is only known from the spec when its SHA256 matches the
+	// deployed code.
+	snapshot.Code = &svcapitypes.FunctionCode{SHA256: ko.Status.CodeSHA256}
+	code := ko.Spec.Code
+	switch {
+	case ko.Spec.PackageType != nil && *ko.Spec.PackageType == string(svcapitypes.PackageType_Image):
+		if code != nil {
+			snapshot.Code.ImageURI = code.ImageURI
+		}
+	case code != nil && code.SHA256 != nil && ko.Status.CodeSHA256 != nil && *code.SHA256 == *ko.Status.CodeSHA256:
+		snapshot.Code.S3Bucket = code.S3Bucket
+		snapshot.Code.S3Key = code.S3Key
+		snapshot.Code.S3ObjectVersion = code.S3ObjectVersion
+	case status.LastKnownGood != nil && status.LastKnownGood.Code != nil &&
+		reflect.DeepEqual(status.LastKnownGood.Code.SHA256, ko.Status.CodeSHA256):
+		snapshot.Code = status.LastKnownGood.Code.DeepCopy()
+	}
+	status.LastKnownGood = snapshot
+}
+
+// failedChanges returns the paths of the fields set in the spec that differ
+// from the last known-good snapshot.
+func failedChanges(
+	spec *svcapitypes.FunctionSpec,
+	snapshot *svcapitypes.FunctionSnapshot,
+) []*string {
+	changes := []*string{}
+	add := func(path string, desired, known interface{}) {
+		if reflect.ValueOf(desired).IsNil() || reflect.DeepEqual(desired, known) {
+			return
+		}
+		changes = append(changes, aws.String(path))
+	}
+	if len(spec.Architectures) > 0 && !reflect.DeepEqual(spec.Architectures, snapshot.Architectures) {
+		changes = append(changes, aws.String("Spec.Architectures"))
+	}
+	if spec.Code != nil {
+		knownCode := snapshot.Code
+		if knownCode == nil {
+			knownCode = &svcapitypes.FunctionCode{}
+		}
+		add("Spec.Code.ImageURI", spec.Code.ImageURI, knownCode.ImageURI)
+		add("Spec.Code.SHA256", spec.Code.SHA256, knownCode.SHA256)
+	}
+	add("Spec.DeadLetterConfig", spec.DeadLetterConfig, snapshot.DeadLetterConfig)
+	add("Spec.Description", spec.Description, snapshot.Description)
+	add("Spec.Environment", spec.Environment, snapshot.Environment)
+	add("Spec.EphemeralStorage", spec.EphemeralStorage, snapshot.EphemeralStorage)
+	add("Spec.Handler", spec.Handler, snapshot.Handler)
+	add("Spec.KMSKeyARN", spec.KMSKeyARN, snapshot.KMSKeyARN)
+	if len(spec.Layers) > 0 && !reflect.DeepEqual(spec.Layers, snapshot.Layers) {
+		changes = append(changes, aws.String("Spec.Layers"))
+	}
+	add("Spec.MemorySize", spec.MemorySize, snapshot.MemorySize)
+	add("Spec.Role", spec.Role, snapshot.Role)
+	add("Spec.Runtime", spec.Runtime, snapshot.Runtime)
+	add("Spec.Timeout", spec.Timeout, snapshot.Timeout)
+	add("Spec.TracingConfig", spec.TracingConfig, snapshot.TracingConfig)
+	if spec.VPCConfig != nil {
+		knownVPCConfig := snapshot.VPCConfig
+		if knownVPCConfig == nil {
+			knownVPCConfig = &svcapitypes.VPCConfig{}
+		}
+		if !reflect.DeepEqual(spec.VPCConfig.SubnetIDs, knownVPCConfig.SubnetIDs) ||
+			!reflect.DeepEqual(spec.VPCConfig.SecurityGroupIDs, knownVPCConfig.SecurityGroupIDs) {
+			changes = append(changes, aws.String("Spec.VPCConfig"))
+		}
+	}
+	return changes
+}
+
+// rollbackFailedUpdate restores the last known-good code, or configuration,
+// of the function depending on which of them the failed update changed. It
+// returns the rollback status to record.
+func (rm *resourceManager) rollbackFailedUpdate(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+) (status *svcapitypes.FunctionRollbackStatus, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.rollbackFailedUpdate")
+	defer exit(err)
+
+	status = latest.ko.Status.Rollback.DeepCopy()
+	snapshot := status.LastKnownGood
+	changes := failedChanges(&desired.ko.Spec, snapshot)
+
+	codeChanged := false
+	for _, change := range changes {
+		if strings.HasPrefix(*change, "Spec.Code") || *change == "Spec.Architectures" {
+			codeChanged = true
+		}
+	}
+
+	restored := rm.concreteResource(desired.DeepCopy())
+	applySnapshot(restored.ko, snapshot)
+	if codeChanged {
+		code := restored.ko.Spec.Code
+		delta := ackcompare.NewDelta()
+		switch {
+		case code != nil && code.ImageURI != nil:
+			delta.Add("Spec.Code.ImageURI", nil, code.ImageURI)
+		case code != nil && code.S3Bucket != nil:
+			delta.Add("Spec.Code.SHA256", nil, code.SHA256)
+		default:
+			return nil, ackerr.NewTerminalError(ErrUnknownLastKnownGoodCode)
+		}
+		err = rm.updateFunctionCode(ctx, restored, delta, latest)
+	} else {
+		delta := ackcompare.NewDelta()
+		for _, path := range restoredConfigurationFields {
+			delta.Add(path, nil, nil)
+		}
+		err = rm.updateFunctionConfiguration(ctx, restored, delta)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := metav1.Now()
+	generation := desired.ko.Generation
+	status.FailedChanges = changes
+	status.FailedGeneration = &generation
+	status.FailureReason = latest.ko.Status.LastUpdateStatusReason
+	status.RolledBackAt = &now
+	rlog.Info("rolled back failed function update", "changes", aws.ToStringSlice(changes))
+	return status, nil
+}
+
+// applySnapshot sets the code and configuration fields of the supplied
+// Function to the ones of the snapshot.
+func applySnapshot(
+	ko *svcapitypes.Function,
+	snapshot *svcapitypes.FunctionSnapshot,
+) {
+	snapshot = snapshot.DeepCopy()
+	ko.Spec.Architectures = snapshot.Architectures
+	ko.Spec.Code = snapshot.Code
+	ko.Spec.DeadLetterConfig = snapshot.DeadLetterConfig
+	ko.Spec.Description = snapshot.Description
+	ko.Spec.Environment = snapshot.Environment
+	ko.Spec.EphemeralStorage = snapshot.EphemeralStorage
+	ko.Spec.Handler = snapshot.Handler
+	ko.Spec.KMSKeyARN = snapshot.KMSKeyARN
+	ko.Spec.Layers = snapshot.Layers
+	ko.Spec.MemorySize = snapshot.MemorySize
+	ko.Spec.Role = snapshot.Role
+	ko.Spec.Runtime = snapshot.Runtime
+	ko.Spec.Timeout = snapshot.Timeout
+	ko.Spec.TracingConfig = snapshot.TracingConfig
+	ko.Spec.VPCConfig = snapshot.VPCConfig
+}
+
+// setRolledBackCondition sets the RolledBack condition of the function from
+// its rollback status. The condition is True while the current generation is
+// the one whose update failed and was rolled back, and False once a newer
+// generation superseded it.
+func setRolledBackCondition(ko *svcapitypes.Function) {
+	status := ko.Status.Rollback
+	if !rollbackEnabled(ko) || status == nil || status.FailedGeneration == nil {
+		return
+	}
+	if *status.FailedGeneration == ko.Generation {
+		setCondition(ko, ConditionTypeRolledBack, corev1.ConditionTrue, rolledBackMessage(status))
+		return
+	}
+	setCondition(ko, ConditionTypeRolledBack, corev1.ConditionFalse, "the failed change was superseded by a new generation")
+}
+
+// rolledBackMessage describes the rolled back update for the RolledBack
+// condition.
+func rolledBackMessage(status *svcapitypes.FunctionRollbackStatus) string {
+	message := fmt.Sprintf(
+		"update of generation %d failed and was rolled back, failed changes: %s",
+		aws.ToInt64(status.FailedGeneration),
+		strings.Join(aws.ToStringSlice(status.FailedChanges), ", "),
+	)
+	if status.FailureReason != nil {
+		message += ": " + *status.FailureReason
+	}
+	return message
+}
@@ -0,0 +1,107 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package function
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	corev1 "k8s.io/api/core/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+)
+
+func Test_failedChanges(t *testing.T) {
+	snapshot := &svcapitypes.FunctionSnapshot{
+		Code: &svcapitypes.FunctionCode{
+			ImageURI: aws.String("123456789012.dkr.ecr.us-west-2.amazonaws.com/app:v1"),
+		},
+		Handler:    aws.String("index.handler"),
+		MemorySize: aws.Int64(128),
+		Timeout:    aws.Int64(3),
+		VPCConfig: &svcapitypes.VPCConfig{
+			SubnetIDs: aws.StringSlice([]string{"subnet-1"}),
+		},
+	}
+	tests := []struct {
+		name string
+		spec *svcapitypes.FunctionSpec
+		want []string
+	}{
+		{
+			name: "unchanged",
+			spec: &svcapitypes.FunctionSpec{
+				Code: &svcapitypes.FunctionCode{
+					ImageURI: aws.String("123456789012.dkr.ecr.us-west-2.amazonaws.com/app:v1"),
+				},
+				MemorySize: aws.Int64(128),
+			},
+			want: []string{},
+		},
+		{
+			name: "image and memory changed",
+			spec: &svcapitypes.FunctionSpec{
+				Code: &svcapitypes.FunctionCode{
+					ImageURI: aws.String("123456789012.dkr.ecr.us-west-2.amazonaws.com/app:v2"),
+				},
+				MemorySize: aws.Int64(256),
+			},
+			want: []string{"Spec.Code.ImageURI", "Spec.MemorySize"},
+		},
+		{
+			name: "subnet changed",
+			spec: &svcapitypes.FunctionSpec{
+				VPCConfig: &svcapitypes.VPCConfig{
+					SubnetIDs: aws.StringSlice([]string{"subnet-2"}),
+				},
+			},
+			want: []string{"Spec.VPCConfig"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := aws.ToStringSlice(failedChanges(tt.spec, snapshot))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("failedChanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_setRolledBackCondition(t *testing.T) {
+	ko := &svcapitypes.Function{
+		Spec: svcapitypes.FunctionSpec{RollbackOnFailure: aws.Bool(true)},
+		Status: svcapitypes.FunctionStatus{
+			Rollback: &svcapitypes.FunctionRollbackStatus{FailedGeneration: aws.Int64(2)},
+		},
+	}
+	ko.Generation = 2
+	// The condition is derived again on every read, after the conditions
+	// were reset.
+	for i := 0; i < 2; i++ {
+		ko.Status.Conditions = nil
+		setRolledBackCondition(ko)
+		if len(ko.Status.Conditions) != 1 || ko.Status.Conditions[0].Status != corev1.ConditionTrue {
+			t.Fatalf("read %d: expected a True RolledBack condition, got %v", i, ko.Status.Conditions)
+		}
+	}
+
+	ko.Generation = 3
+	ko.Status.Conditions = nil
+	setRolledBackCondition(ko)
+	if len(ko.Status.Conditions) != 1 || ko.Status.Conditions[0].Status != corev1.ConditionFalse {
+		t.Fatalf("expected a False RolledBack condition once superseded, got %v", ko.Status.Conditions)
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package function
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/lambda"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/lambda-controller/pkg/resource/invoke"
+)
+
+// ConditionTypeSmokeTestPassed is raised on a Function once the smoke test
+// ran against its current code. Its status is false when the smoke test
+// failed, and unknown until the smoke test runs against new code.
+const ConditionTypeSmokeTestPassed ackv1alpha1.ConditionType = "SmokeTestPassed"
+
+// maxSmokeTestResultLength bounds the length of the function result quoted in
+// the smoke test message.
+const maxSmokeTestResultLength = 256
+
+var (
+	ErrSmokeTestPayloadConflict = errors.New("only one of SmokeTest.Payload and SmokeTest.PayloadFrom can be set")
+)
+
+// resolveSmokeTestPayload reads the smoke test payload from the ConfigMap key
+// referenced by SmokeTest.PayloadFrom.
+func (rm *resourceManager) resolveSmokeTestPayload(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.Function,
+) error {
+	if ko.Spec.SmokeTest == nil || ko.Spec.SmokeTest.PayloadFrom == nil {
+		return nil
+	}
+	if ko.Spec.SmokeTest.Payload != nil {
+		return ackerr.NewTerminalError(ErrSmokeTestPayloadConflict)
+	}
+	from := ko.Spec.SmokeTest.PayloadFrom
+	if from.ConfigMapName == nil || from.Key == nil {
+		return fmt.Errorf("provided ConfigMap reference is nil or empty: SmokeTest.PayloadFrom")
+	}
+	namespace := ko.ObjectMeta.GetNamespace()
+	configMap := &corev1.ConfigMap{}
+	err := apiReader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: *from.ConfigMapName}, configMap)
+	if err != nil {
+		return err
+	}
+	payload, found := configMap.Data[*from.Key]
+	if !found {
+		return fmt.Errorf("key %s not found in ConfigMap %s/%s", *from.Key, namespace, *from.ConfigMapName)
+	}
+	ko.Spec.SmokeTest.Payload = &payload
+	return nil
+}
+
+// smokeTestPending returns true if the smoke test didn't run against the
+// current code of the function yet.
+func smokeTestPending(
+	desired *resource,
+	latest *resource,
+) bool {
+	if desired.ko.Spec.SmokeTest == nil || latest.ko.Status.CodeSHA256 == nil ||
+		isFunctionUpdateFailed(latest) {
+		return false
+	}
+	result := latest.ko.Status.SmokeTestResult
+	return result == nil || result.CodeSHA256 == nil || *result.CodeSHA256 != *latest.ko.Status.CodeSHA256
+}
+
+// smokeTestPassed returns true if the smoke test passed against the current
+// code of the function.
+func smokeTestPassed(r *resource) bool {
+	result := r.ko.Status.SmokeTestResult
+	return result != nil && result.Passed != nil && *result.Passed &&
+		result.CodeSHA256 != nil && r.ko.Status.CodeSHA256 != nil &&
+		*result.CodeSHA256 == *r.ko.Status.CodeSHA256
+}
+
+// runSmokeTest invokes the function synchronously with the smoke test
+// payload and returns the outcome to record.
+func (rm *resourceManager) runSmokeTest(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+) (result *svcapitypes.FunctionSmokeTestStatus, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.runSmokeTest")
+	defer exit(err)
+
+	smokeTest := desired.ko.Spec.SmokeTest
+	input := &svcsdk.InvokeInput{
+		FunctionName:   desired.ko.Spec.Name,
+		InvocationType: svcsdktypes.InvocationTypeRequestResponse,
+		LogType:        svcsdktypes.LogTypeTail,
+	}
+	if smokeTest.Payload != nil {
+		input.Payload = []byte(*smokeTest.Payload)
+	}
+	var resp *svcsdk.InvokeOutput
+	resp, err = rm.sdkapi.Invoke(ctx, input)
+	rm.metrics.RecordAPICall("INVOKE", "Invoke", err)
+	if err != nil {
+		return nil, err
+	}
+
+	now := metav1.Now()
+	result = &svcapitypes.FunctionSmokeTestStatus{
+		CodeSHA256: latest.ko.Status.CodeSHA256,
+		TestedAt:   &now,
+	}
+	if requestID, ok := awsmiddleware.GetRequestIDMetadata(resp.ResultMetadata); ok {
+		result.RequestID = aws.String(requestID)
+	}
+	if resp.LogResult != nil {
+		if logTail, err := base64.StdEncoding.DecodeString(*resp.LogResult); err == nil {
+			result.LogTail = aws.String(string(logTail))
+		}
+	}
+	passed, message := evaluateSmokeTest(resp.FunctionError, resp.Payload, smokeTest.ResultAssertion)
+	result.Passed = aws.Bool(passed)
+	result.Message = aws.String(message)
+	rlog.Info("ran function smoke test", "passed", passed, "requestID", aws.ToString(result.RequestID))
+	return result, nil
+}
+
+// evaluateSmokeTest checks the response of a smoke test invocation and
+// returns whether it passed, along with a message describing the outcome.
+func evaluateSmokeTest(
+	functionError *string,
+	payload []byte,
+	assertion *svcapitypes.FunctionSmokeTestAssertion,
+) (bool, string) {
+	if functionError != nil {
+		return false, fmt.Sprintf("function returned an error (%s): %s", *functionError, invoke.TruncatePayload(payload, maxSmokeTestResultLength))
+	}
+	if assertion == nil || assertion.JSONPath == nil {
+		return true, "function returned a non-error response"
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return false, fmt.Sprintf("function result is not valid JSON: %s", invoke.TruncatePayload(payload, maxSmokeTestResultLength))
+	}
+	jp := jsonpath.New("smokeTest")
+	if err := jp.Parse(*assertion.JSONPath); err != nil {
+		return false, fmt.Sprintf("invalid JSONPath %s: %v", *assertion.JSONPath, err)
+	}
+	var buf bytes.Buffer
+	if err := jp.Execute(&buf, data); err != nil {
+		return false, fmt.Sprintf("evaluating %s on the function result: %v", *assertion.JSONPath, err)
+	}
+	got := buf.String()
+	if assertion.Equals != nil && got != *assertion.Equals {
+		return false, fmt.Sprintf("%s is %q, expected %q", *assertion.JSONPath, got, *assertion.Equals)
+	}
+	if got == "" {
+		return false, fmt.Sprintf("%s is empty", *assertion.JSONPath)
+	}
+	return true, fmt.Sprintf("%s is %q", *assertion.JSONPath, got)
+}
+
+// setSmokeTestPassedCondition sets the SmokeTestPassed condition on the
+// supplied Function from the recorded smoke test outcome. The outcome only
+// holds for the code it was tested against, the condition is Unknown until
+// the smoke test runs against the current code.
+func setSmokeTestPassedCondition(ko *svcapitypes.Function) {
+	result := ko.Status.SmokeTestResult
+	if ko.Spec.SmokeTest == nil || result == nil {
+		return
+	}
+	if result.CodeSHA256 == nil || ko.Status.CodeSHA256 == nil || *result.CodeSHA256 != *ko.Status.CodeSHA256 {
+		setCondition(ko, ConditionTypeSmokeTestPassed, corev1.ConditionUnknown, "the smoke test didn't run against the current code yet")
+		return
+	}
+	status := corev1.ConditionFalse
+	if result.Passed != nil && *result.Passed {
+		status = corev1.ConditionTrue
+	}
+	message := fmt.Sprintf("request %s: %s", aws.ToString(result.RequestID), aws.ToString(result.Message))
+	setCondition(ko, ConditionTypeSmokeTestPassed, status, message)
+}
@@ -0,0 +1,174 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package function
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/lambda"
+	corev1 "k8s.io/api/core/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+)
+
+func Test_evaluateSmokeTest(t *testing.T) {
+	tests := []struct {
+		name          string
+		functionError *string
+		payload       string
+		assertion     *svcapitypes.FunctionSmokeTestAssertion
+		want          bool
+	}{
+		{
+			name:    "no assertion",
+			payload: `{"statusCode": 200}`,
+			want:    true,
+		},
+		{
+			name:          "function error",
+			functionError: aws.String("Unhandled"),
+			payload:       `{"errorMessage": "boom"}`,
+			want:          false,
+		},
+		{
+			name:    "assertion matches",
+			payload: `{"statusCode": 200}`,
+			assertion: &svcapitypes.FunctionSmokeTestAssertion{
+				JSONPath: aws.String("{.statusCode}"),
+				Equals:   aws.String("200"),
+			},
+			want: true,
+		},
+		{
+			name:    "assertion doesn't match",
+			payload: `{"statusCode": 500}`,
+			assertion: &svcapitypes.FunctionSmokeTestAssertion{
+				JSONPath: aws.String("{.statusCode}"),
+				Equals:   aws.String("200"),
+			},
+			want: false,
+		},
+		{
+			name:    "result isn't JSON",
+			payload: `ok`,
+			assertion: &svcapitypes.FunctionSmokeTestAssertion{
+				JSONPath: aws.String("{.statusCode}"),
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, message := evaluateSmokeTest(tt.functionError, []byte(tt.payload), tt.assertion)
+			if got != tt.want {
+				t.Errorf("evaluateSmokeTest() = %v (%s), want %v", got, message, tt.want)
+			}
+		})
+	}
+}
+
+// notFoundClient answers every Lambda API call with a
+// ResourceNotFoundException, so that the optional function settings read
+// along with the function are reported as absent.
+type notFoundClient struct{}
+
+func (notFoundClient) Do(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusNotFound,
+		Header: http.Header{
+			"Content-Type":     []string{"application/json"},
+			"X-Amzn-Errortype": []string{"ResourceNotFoundException"},
+			"X-Amzn-Requestid": []string{"test"},
+		},
+		Body:    io.NopCloser(strings.NewReader(`{"Type":"User","Message":"not found"}`)),
+		Request: req,
+	}, nil
+}
+
+func Test_setResourceAdditionalFields_smokeTestPassedCondition(t *testing.T) {
+	rm := &resourceManager{
+		metrics: ackmetrics.NewMetrics("lambda"),
+		sdkapi: svcsdk.New(svcsdk.Options{
+			Region:           "us-west-2",
+			Credentials:      aws.AnonymousCredentials{},
+			HTTPClient:       notFoundClient{},
+			RetryMaxAttempts: 1,
+		}),
+	}
+	ko := &svcapitypes.Function{
+		Spec: svcapitypes.FunctionSpec{
+			Name:      aws.String("my-function"),
+			SmokeTest: &svcapitypes.FunctionSmokeTest{},
+		},
+		Status: svcapitypes.FunctionStatus{
+			CodeSHA256: aws.String("sha-1"),
+			SmokeTestResult: &svcapitypes.FunctionSmokeTestStatus{
+				CodeSHA256: aws.String("sha-1"),
+				Passed:     aws.Bool(true),
+			},
+		},
+	}
+
+	// The reconciler resets the conditions before every read, the condition
+	// has to be derived again from the recorded result each time.
+	for i := 0; i < 2; i++ {
+		ko.Status.Conditions = nil
+		if err := rm.setResourceAdditionalFields(context.Background(), ko); err != nil {
+			t.Fatalf("read %d: %v", i, err)
+		}
+		if len(ko.Status.Conditions) != 1 ||
+			ko.Status.Conditions[0].Type != ConditionTypeSmokeTestPassed ||
+			ko.Status.Conditions[0].Status != corev1.ConditionTrue {
+			t.Fatalf("read %d: expected a True SmokeTestPassed condition, got %v", i, ko.Status.Conditions)
+		}
+	}
+
+	// A result recorded for other code doesn't hold anymore.
+	ko.Status.CodeSHA256 = aws.String("sha-2")
+	ko.Status.Conditions = nil
+	if err := rm.setResourceAdditionalFields(context.Background(), ko); err != nil {
+		t.Fatal(err)
+	}
+	if len(ko.Status.Conditions) != 1 || ko.Status.Conditions[0].Status != corev1.ConditionUnknown {
+		t.Fatalf("expected an Unknown SmokeTestPassed condition, got %v", ko.Status.Conditions)
+	}
+}
+
+func Test_ClearResolvedReferences_smokeTestPayload(t *testing.T) {
+	rm := &resourceManager{}
+	r := &resource{ko: &svcapitypes.Function{
+		Spec: svcapitypes.FunctionSpec{
+			SmokeTest: &svcapitypes.FunctionSmokeTest{
+				Payload: aws.String(`{"ping":true}`),
+				PayloadFrom: &svcapitypes.FunctionSmokeTestPayloadSource{
+					ConfigMapName: aws.String("smoke-test"),
+					Key:           aws.String("payload.json"),
+				},
+			},
+		},
+	}}
+	cleared := rm.ClearResolvedReferences(r).(*resource)
+	if cleared.ko.Spec.SmokeTest.Payload != nil {
+		t.Errorf("expected the resolved payload to be cleared, got %q", *cleared.ko.Spec.SmokeTest.Payload)
+	}
+	if r.ko.Spec.SmokeTest.Payload == nil {
+		t.Errorf("expected the supplied resource to be left alone")
+	}
+}
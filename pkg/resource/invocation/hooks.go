@@ -0,0 +1,265 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package invocation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrt "github.com/aws-controllers-k8s/runtime/pkg/runtime"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/lambda"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/lambda-controller/pkg/resource/invoke"
+)
+
+const (
+	// defaultBackoffLimit is the number of retries when Spec.BackoffLimit
+	// isn't set, as for Kubernetes Jobs.
+	defaultBackoffLimit = 6
+	// initialBackoff and maxBackoff bound the delay between two attempts,
+	// which doubles after every failed attempt.
+	initialBackoff = 10 * time.Second
+	maxBackoff     = 6 * time.Minute
+	// maxResultPayloadLength bounds the length of the function response
+	// recorded in the status.
+	maxResultPayloadLength = 4096
+)
+
+const (
+	stateRetrying  = "Retrying"
+	stateSucceeded = "Succeeded"
+	stateFailed    = "Failed"
+)
+
+var (
+	ErrInvocationCompleted = errors.New("invocation already completed, its spec can't be changed")
+	ErrBackingOff          = errors.New("invocation failed, waiting before retrying it")
+)
+
+// customFindInvocation returns the supplied resource once the function was
+// invoked. Invocations aren't stored by Lambda, the outcome of the last
+// attempt is only recorded in the status.
+func (rm *resourceManager) customFindInvocation(
+	ctx context.Context,
+	r *resource,
+) (*resource, error) {
+	if r.ko.Status.Attempts == nil {
+		return nil, ackerr.NotFound
+	}
+	return &resource{r.ko.DeepCopy()}, nil
+}
+
+// customPreCompare adds a difference until the invocation of the current
+// generation of the spec completed. Incomplete invocations are retried, and
+// changes to the spec of a completed invocation are refused by the update.
+func customPreCompare(
+	delta *ackcompare.Delta,
+	a *resource,
+	b *resource,
+) {
+	completed := b.ko.Status.CompletedGeneration
+	if completed == nil || a.ko.Generation != *completed {
+		delta.Add("Spec", a.ko.Generation, completed)
+	}
+}
+
+// customUpdateInvocation retries the invocation once the back-off delay since
+// the last failed attempt elapsed. Completed invocations can't be updated.
+func (rm *resourceManager) customUpdateInvocation(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+	delta *ackcompare.Delta,
+) (updated *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.customUpdateInvocation")
+	defer exit(err)
+
+	if invocationCompleted(latest.ko) {
+		return nil, ackerr.NewTerminalError(ErrInvocationCompleted)
+	}
+	if wait := retryDelay(latest.ko, time.Now()); wait > 0 {
+		return latest, ackrequeue.NeededAfter(ErrBackingOff, wait)
+	}
+	rlog.Info("retrying invocation", "attempts", aws.ToInt64(latest.ko.Status.Attempts))
+	ko := desired.ko.DeepCopy()
+	ko.Status = *latest.ko.Status.DeepCopy()
+	return rm.sdkCreate(ctx, &resource{ko})
+}
+
+// invocationCompleted returns true if the invocation succeeded or failed
+// after exhausting its retries.
+func invocationCompleted(ko *svcapitypes.Invocation) bool {
+	state := aws.ToString(ko.Status.State)
+	return state == stateSucceeded || state == stateFailed
+}
+
+// backoffLimit returns the number of retries of the supplied invocation.
+func backoffLimit(ko *svcapitypes.Invocation) int64 {
+	if ko.Spec.BackoffLimit == nil {
+		return defaultBackoffLimit
+	}
+	return *ko.Spec.BackoffLimit
+}
+
+// retryDelay returns how long to wait before retrying the invocation. The
+// delay doubles after every failed attempt, up to maxBackoff.
+func retryDelay(ko *svcapitypes.Invocation, now time.Time) time.Duration {
+	if ko.Status.Attempts == nil || ko.Status.LastAttemptTime == nil {
+		return 0
+	}
+	backoff := initialBackoff
+	for i := int64(1); i < *ko.Status.Attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return ko.Status.LastAttemptTime.Add(backoff).Sub(now)
+}
+
+// recordAttempt counts a new invocation attempt in the status of the
+// supplied Invocation and returns the number of attempts so far.
+func recordAttempt(ko *svcapitypes.Invocation, now metav1.Time) int64 {
+	attempts := int64(1)
+	if ko.Status.Attempts != nil {
+		attempts = *ko.Status.Attempts + 1
+	}
+	ko.Status.Attempts = &attempts
+	ko.Status.LastAttemptTime = &now
+	ko.Status.ResultPayload = nil
+	return attempts
+}
+
+// setCompleted records the completion of the invocation of the current
+// generation of the spec.
+func setCompleted(ko *svcapitypes.Invocation, state string, now metav1.Time) {
+	generation := ko.Generation
+	ko.Status.State = aws.String(state)
+	ko.Status.CompletedGeneration = &generation
+	ko.Status.CompletionTime = &now
+}
+
+// setInvocationResult records the outcome of an invocation attempt in the
+// status of the supplied Invocation.
+func setInvocationResult(
+	ko *svcapitypes.Invocation,
+	resp *svcsdk.InvokeOutput,
+) {
+	now := metav1.Now()
+	attempts := recordAttempt(ko, now)
+	if len(resp.Payload) > 0 {
+		ko.Status.ResultPayload = aws.String(invoke.TruncatePayload(resp.Payload, maxResultPayloadLength))
+	}
+
+	switch {
+	case resp.FunctionError == nil:
+		setCompleted(ko, stateSucceeded, now)
+	case attempts > backoffLimit(ko):
+		setCompleted(ko, stateFailed, now)
+	default:
+		ko.Status.State = aws.String(stateRetrying)
+	}
+}
+
+// setInvokeError records an Invoke call that failed, such as a throttled or
+// denied call, as a failed attempt. The invocation is retried after the
+// back-off delay, and fails with a terminal error once its retries are
+// exhausted.
+//
+// This function is used as a sdk_create_post_request hook.
+func setInvokeError(
+	desired *resource,
+	invokeErr error,
+) (*resource, error) {
+	ko := desired.ko.DeepCopy()
+	now := metav1.Now()
+	ko.Status.FunctionError = nil
+	ko.Status.StatusCode = nil
+	if attempts := recordAttempt(ko, now); attempts > backoffLimit(ko) {
+		setCompleted(ko, stateFailed, now)
+		return &resource{ko}, ackerr.NewTerminalError(invokeErr)
+	}
+	ko.Status.State = aws.String(stateRetrying)
+	return &resource{ko}, ackrequeue.NeededAfter(invokeErr, retryDelay(ko, now.Time))
+}
+
+// resolveReferenceForAlias reads the Alias referenced from the AliasRef field
+// and sets the Qualifier from its name.
+func (rm *resourceManager) resolveReferenceForAlias(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.Invocation,
+) (hasReferences bool, err error) {
+	if ko.Spec.AliasRef == nil || ko.Spec.AliasRef.From == nil {
+		return false, nil
+	}
+	hasReferences = true
+	if ko.Spec.QualifierRef != nil || ko.Spec.Qualifier != nil {
+		return hasReferences, ackerr.ResourceReferenceAndIDNotSupportedFor("Qualifier", "AliasRef")
+	}
+	arr := ko.Spec.AliasRef.From
+	if arr.Name == nil || *arr.Name == "" {
+		return hasReferences, fmt.Errorf("provided resource reference is nil or empty: AliasRef")
+	}
+	namespace, err := ackrt.ResolveCrossNamespaceReference(
+		ctx,
+		rm.cfg.EnableCrossNamespace,
+		&ko.Status.Conditions,
+		ackrt.CrossNamespaceRefKindResource,
+		ko.ObjectMeta.GetNamespace(),
+		arr.Namespace,
+		*arr.Name,
+	)
+	if err != nil {
+		return hasReferences, err
+	}
+	obj := &svcapitypes.Alias{}
+	if err := apiReader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: *arr.Name}, obj); err != nil {
+		return hasReferences, err
+	}
+	var synced bool
+	for _, cond := range obj.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case ackv1alpha1.ConditionTypeTerminal:
+			return hasReferences, ackerr.ResourceReferenceTerminalFor("Alias", namespace, *arr.Name)
+		case ackv1alpha1.ConditionTypeResourceSynced:
+			synced = true
+		}
+	}
+	if !synced {
+		return hasReferences, ackerr.ResourceReferenceNotSyncedFor("Alias", namespace, *arr.Name)
+	}
+	if obj.Spec.Name == nil {
+		return hasReferences, ackerr.ResourceReferenceMissingTargetFieldFor("Alias", namespace, *arr.Name, "Spec.Name")
+	}
+	ko.Spec.Qualifier = obj.Spec.Name
+	return hasReferences, nil
+}
@@ -0,0 +1,225 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package invocation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/lambda"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+)
+
+func Test_retryDelay(t *testing.T) {
+	now := time.Now()
+	lastAttempt := metav1.NewTime(now.Add(-5 * time.Second))
+	tests := []struct {
+		name     string
+		attempts *int64
+		want     time.Duration
+	}{
+		{
+			name: "never invoked",
+			want: 0,
+		},
+		{
+			name:     "first retry",
+			attempts: aws.Int64(1),
+			want:     5 * time.Second,
+		},
+		{
+			name:     "third retry",
+			attempts: aws.Int64(3),
+			want:     35 * time.Second,
+		},
+		{
+			name:     "capped",
+			attempts: aws.Int64(20),
+			want:     6*time.Minute - 5*time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ko := &svcapitypes.Invocation{}
+			ko.Status.Attempts = tt.attempts
+			if tt.attempts != nil {
+				ko.Status.LastAttemptTime = &lastAttempt
+			}
+			if got := retryDelay(ko, now); got != tt.want {
+				t.Errorf("retryDelay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_setInvocationResult(t *testing.T) {
+	tests := []struct {
+		name          string
+		attempts      *int64
+		functionError *string
+		wantState     string
+		wantCompleted bool
+	}{
+		{
+			name:          "succeeded",
+			wantState:     stateSucceeded,
+			wantCompleted: true,
+		},
+		{
+			name:          "function error",
+			functionError: aws.String("Unhandled"),
+			wantState:     stateRetrying,
+		},
+		{
+			name:          "last retry",
+			attempts:      aws.Int64(defaultBackoffLimit - 1),
+			functionError: aws.String("Unhandled"),
+			wantState:     stateRetrying,
+		},
+		{
+			name:          "retries exhausted",
+			attempts:      aws.Int64(defaultBackoffLimit),
+			functionError: aws.String("Unhandled"),
+			wantState:     stateFailed,
+			wantCompleted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ko := &svcapitypes.Invocation{ObjectMeta: metav1.ObjectMeta{Generation: 2}}
+			ko.Status.Attempts = tt.attempts
+			setInvocationResult(ko, &svcsdk.InvokeOutput{
+				FunctionError: tt.functionError,
+				Payload:       []byte(`{"ok":true}`),
+			})
+			if got := aws.ToString(ko.Status.State); got != tt.wantState {
+				t.Errorf("State = %q, want %q", got, tt.wantState)
+			}
+			if want := aws.ToInt64(tt.attempts) + 1; aws.ToInt64(ko.Status.Attempts) != want {
+				t.Errorf("Attempts = %d, want %d", aws.ToInt64(ko.Status.Attempts), want)
+			}
+			if aws.ToString(ko.Status.ResultPayload) != `{"ok":true}` {
+				t.Errorf("ResultPayload = %v, want the function response", ko.Status.ResultPayload)
+			}
+			if completed := ko.Status.CompletedGeneration != nil; completed != tt.wantCompleted {
+				t.Fatalf("completed = %v, want %v", completed, tt.wantCompleted)
+			}
+			if tt.wantCompleted && (*ko.Status.CompletedGeneration != 2 || ko.Status.CompletionTime == nil) {
+				t.Errorf("expected generation 2 to be completed, got %d", *ko.Status.CompletedGeneration)
+			}
+		})
+	}
+}
+
+func Test_setInvokeError(t *testing.T) {
+	invokeErr := errors.New("throttled")
+
+	r := &resource{ko: &svcapitypes.Invocation{}}
+	latest, err := setInvokeError(r, invokeErr)
+	var requeueErr *ackrequeue.RequeueNeededAfter
+	if !errors.As(err, &requeueErr) || requeueErr.Duration() != initialBackoff {
+		t.Fatalf("expected a requeue after %v, got %v", initialBackoff, err)
+	}
+	if aws.ToInt64(latest.ko.Status.Attempts) != 1 || aws.ToString(latest.ko.Status.State) != stateRetrying {
+		t.Errorf("expected a first retrying attempt, got %d attempts in state %q",
+			aws.ToInt64(latest.ko.Status.Attempts), aws.ToString(latest.ko.Status.State))
+	}
+	if r.ko.Status.Attempts != nil {
+		t.Errorf("expected the supplied resource to be left alone")
+	}
+
+	r.ko.Spec.BackoffLimit = aws.Int64(1)
+	r.ko.Status.Attempts = aws.Int64(1)
+	latest, err = setInvokeError(r, invokeErr)
+	var terminalErr *ackerr.TerminalError
+	if !errors.As(err, &terminalErr) {
+		t.Fatalf("expected a terminal error once the retries are exhausted, got %v", err)
+	}
+	if aws.ToString(latest.ko.Status.State) != stateFailed || latest.ko.Status.CompletedGeneration == nil {
+		t.Errorf("expected the invocation to be failed, got state %q", aws.ToString(latest.ko.Status.State))
+	}
+}
+
+func Test_customPreCompare(t *testing.T) {
+	tests := []struct {
+		name      string
+		completed *int64
+		want      bool
+	}{
+		{
+			name: "not completed",
+			want: true,
+		},
+		{
+			name:      "completed",
+			completed: aws.Int64(2),
+			want:      false,
+		},
+		{
+			name:      "spec changed after completion",
+			completed: aws.Int64(1),
+			want:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired := &resource{ko: &svcapitypes.Invocation{ObjectMeta: metav1.ObjectMeta{Generation: 2}}}
+			latest := &resource{ko: desired.ko.DeepCopy()}
+			latest.ko.Status.CompletedGeneration = tt.completed
+			delta := ackcompare.NewDelta()
+			customPreCompare(delta, desired, latest)
+			if got := delta.DifferentAt("Spec"); got != tt.want {
+				t.Errorf("DifferentAt(Spec) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_customUpdateInvocation_completed(t *testing.T) {
+	rm := &resourceManager{}
+	desired := &resource{ko: &svcapitypes.Invocation{ObjectMeta: metav1.ObjectMeta{Generation: 2}}}
+	latest := &resource{ko: desired.ko.DeepCopy()}
+	latest.ko.Status.State = aws.String(stateSucceeded)
+	latest.ko.Status.CompletedGeneration = aws.Int64(1)
+	_, err := rm.customUpdateInvocation(context.TODO(), desired, latest, ackcompare.NewDelta())
+	var terminalErr *ackerr.TerminalError
+	if !errors.As(err, &terminalErr) || !errors.Is(err, ErrInvocationCompleted) {
+		t.Errorf("expected a terminal ErrInvocationCompleted, got %v", err)
+	}
+}
+
+func Test_ClearResolvedReferences_alias(t *testing.T) {
+	rm := &resourceManager{}
+	r := &resource{ko: &svcapitypes.Invocation{
+		Spec: svcapitypes.InvocationSpec{
+			Qualifier: aws.String("live"),
+			AliasRef: &ackv1alpha1.AWSResourceReferenceWrapper{
+				From: &ackv1alpha1.AWSResourceReference{Name: aws.String("live")},
+			},
+		},
+	}}
+	cleared := rm.ClearResolvedReferences(r).(*resource)
+	if cleared.ko.Spec.Qualifier != nil {
+		t.Errorf("expected the resolved Qualifier to be cleared, got %q", *cleared.ko.Spec.Qualifier)
+	}
+}
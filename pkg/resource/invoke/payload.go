@@ -0,0 +1,28 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package invoke holds helpers shared by the resources invoking functions.
+package invoke
+
+import "strings"
+
+// TruncatePayload returns the supplied payload as a string of at most n
+// bytes. A UTF-8 sequence split by the cut, and any invalid UTF-8 sequence,
+// is dropped so that the result is safe to record in the status of a
+// resource.
+func TruncatePayload(payload []byte, n int) string {
+	if len(payload) > n {
+		payload = payload[:n]
+	}
+	return strings.ToValidUTF8(string(payload), "")
+}
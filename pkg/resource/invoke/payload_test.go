@@ -0,0 +1,45 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package invoke
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncatePayload(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		n       int
+		want    string
+	}{
+		{name: "short", payload: "ok", n: 4, want: "ok"},
+		{name: "exact", payload: "four", n: 4, want: "four"},
+		{name: "long", payload: "too long", n: 3, want: "too"},
+		{name: "split rune", payload: "café", n: 4, want: "caf"},
+		{name: "invalid", payload: "a\xffb", n: 8, want: "ab"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TruncatePayload([]byte(tt.payload), tt.n)
+			if got != tt.want {
+				t.Errorf("TruncatePayload() = %q, want %q", got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("TruncatePayload() = %q, not valid UTF-8", got)
+			}
+		})
+	}
+}
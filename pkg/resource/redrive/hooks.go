@@ -0,0 +1,475 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package redrive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/lambda"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/lambda-controller/pkg/resource/reference"
+)
+
+const (
+	// defaultBatchSize and maxBatchSize bound the number of messages read
+	// from the queue at once. SQS returns at most 10 messages per call.
+	defaultBatchSize = 10
+	maxBatchSize     = 10
+	// defaultMessagesPerSecond is the redrive rate when
+	// Spec.MessagesPerSecond isn't set.
+	defaultMessagesPerSecond = 10
+	// receiveVisibilityTimeout hides the received messages from other
+	// consumers while they're redriven.
+	receiveVisibilityTimeout = 30
+	// receiveWaitTime makes SQS look for messages on all its servers, so an
+	// empty response means the queue is drained.
+	receiveWaitTime = 1
+	// failedVisibilityTimeout hides the messages that couldn't be redriven,
+	// or deleted, for the longest time SQS supports, so they're kept in the
+	// queue without being retried by the same redrive.
+	failedVisibilityTimeout = 12 * 60 * 60
+	// throttledDelay is how long to wait before redriving again after Lambda
+	// throttled an invocation.
+	throttledDelay = 10 * time.Second
+	// minRequeueDelay is the shortest delay between two batches.
+	minRequeueDelay = 100 * time.Millisecond
+)
+
+const (
+	stateInProgress = "InProgress"
+	stateCompleted  = "Completed"
+)
+
+// +kubebuilder:rbac:groups=sqs.services.k8s.aws,resources=queues,verbs=get;list
+// +kubebuilder:rbac:groups=sqs.services.k8s.aws,resources=queues/status,verbs=get;list
+
+// queueGVK is the kind of the queues managed by the ACK controller for SQS.
+var queueGVK = schema.GroupVersionKind{
+	Group:   "sqs.services.k8s.aws",
+	Version: "v1alpha1",
+	Kind:    "Queue",
+}
+
+var (
+	ErrQueueRequired = errors.New("one of QueueARN or QueueRef is required")
+)
+
+// customFindRedrive returns the supplied resource once the redrive started.
+// Redrives aren't stored by Lambda, their progress is only recorded in the
+// status.
+func (rm *resourceManager) customFindRedrive(
+	ctx context.Context,
+	r *resource,
+) (*resource, error) {
+	if r.ko.Status.State == nil {
+		return nil, ackerr.NotFound
+	}
+	return &resource{r.ko.DeepCopy()}, nil
+}
+
+// customPreCompare adds a difference until the queue is drained, so that
+// the next batch of messages is redriven. The difference is added to the
+// spec, as the runtime only updates resources whose spec differs.
+func customPreCompare(
+	delta *ackcompare.Delta,
+	a *resource,
+	b *resource,
+) {
+	if !redriveCompleted(b.ko) {
+		delta.Add("Spec", stateCompleted, b.ko.Status.State)
+	}
+}
+
+// customUpdateRedrive redrives the next messages of the queue, at most
+// Spec.MessagesPerSecond messages per second, and completes the redrive once
+// the queue is drained. Changes to the spec of a completed redrive are
+// ignored.
+func (rm *resourceManager) customUpdateRedrive(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+	delta *ackcompare.Delta,
+) (updated *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.customUpdateRedrive")
+	defer exit(err)
+
+	if redriveCompleted(latest.ko) {
+		return latest, nil
+	}
+	if err = validateRedrive(desired.ko); err != nil {
+		return nil, ackerr.NewTerminalError(err)
+	}
+	ko := desired.ko.DeepCopy()
+	ko.Status = *latest.ko.Status.DeepCopy()
+	client, err := rm.sqsClient(*ko.Spec.QueueARN)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	rate := messagesPerSecond(ko)
+	var received, redriven, undeleted, failed int64
+	var throttled bool
+	// withProgress records the messages handled so far in the status, so
+	// that they're counted even when a later call of the batch fails.
+	withProgress := func() *resource {
+		ko.Status.RedrivenMessages = aws.Int64(aws.ToInt64(ko.Status.RedrivenMessages) + redriven)
+		ko.Status.UndeletedMessages = aws.Int64(aws.ToInt64(ko.Status.UndeletedMessages) + undeleted)
+		ko.Status.FailedMessages = aws.Int64(aws.ToInt64(ko.Status.FailedMessages) + failed)
+		return &resource{ko}
+	}
+	for received < rate && !throttled {
+		var resp *sqs.ReceiveMessageOutput
+		resp, err = client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:            ko.Status.QueueURL,
+			MaxNumberOfMessages: int32(min(batchSize(ko), rate-received)),
+			VisibilityTimeout:   receiveVisibilityTimeout,
+			WaitTimeSeconds:     receiveWaitTime,
+		})
+		rm.metrics.RecordAPICall("READ_MANY", "ReceiveMessage", err)
+		if err != nil {
+			return withProgress(), err
+		}
+		if len(resp.Messages) == 0 {
+			break
+		}
+		received += int64(len(resp.Messages))
+		for _, msg := range resp.Messages {
+			var invoked bool
+			invoked, err = rm.redriveMessage(ctx, client, ko, msg)
+			var throttledErr *svcsdktypes.TooManyRequestsException
+			switch {
+			case err == nil:
+				redriven++
+			case invoked:
+				// The event was delivered, only deleting the message failed.
+				// It's hidden rather than redriven again.
+				redriven++
+				undeleted++
+				ko.Status.LastFailureMessage = aws.String(err.Error())
+				err = rm.hideMessage(ctx, client, ko, msg)
+			case errors.As(err, &throttledErr):
+				// The message is received again once its visibility timeout
+				// expires.
+				throttled = true
+				err = nil
+			default:
+				failed++
+				ko.Status.LastFailureMessage = aws.String(err.Error())
+				err = rm.hideMessage(ctx, client, ko, msg)
+			}
+			if err != nil {
+				return withProgress(), err
+			}
+			if throttled {
+				break
+			}
+		}
+	}
+	withProgress()
+	if err = rm.setRemainingMessages(ctx, client, ko); err != nil {
+		return &resource{ko}, err
+	}
+	rlog.Info("redrove messages", "redriven", redriven, "undeleted", undeleted, "failed", failed, "remaining", aws.ToInt64(ko.Status.RemainingMessages))
+
+	if received == 0 && aws.ToInt64(ko.Status.RemainingMessages) == 0 {
+		now := metav1.Now()
+		ko.Status.State = aws.String(stateCompleted)
+		ko.Status.CompletionTime = &now
+		return &resource{ko}, nil
+	}
+	if throttled {
+		return &resource{ko}, ackrequeue.NeededAfter(nil, throttledDelay)
+	}
+	wait := time.Duration(received)*time.Second/time.Duration(rate) - time.Since(start)
+	return &resource{ko}, ackrequeue.NeededAfter(nil, max(wait, minRequeueDelay))
+}
+
+// customDeleteRedrive stops the redrive. Redriven messages were already
+// deleted from the queue, and the messages that couldn't be redriven, or
+// deleted, stay hidden until their visibility timeout of 12 hours expires:
+// their receipt handles aren't kept, so their visibility can't be restored.
+// Messages received by an interrupted batch become visible again after 30
+// seconds.
+func (rm *resourceManager) customDeleteRedrive(
+	ctx context.Context,
+	r *resource,
+) (*resource, error) {
+	rlog := ackrtlog.FromContext(ctx)
+	if hidden := aws.ToInt64(r.ko.Status.FailedMessages) + aws.ToInt64(r.ko.Status.UndeletedMessages); hidden > 0 {
+		rlog.Info("deleting redrive, messages that couldn't be redriven or deleted stay hidden until their visibility timeout expires",
+			"failed", aws.ToInt64(r.ko.Status.FailedMessages),
+			"undeleted", aws.ToInt64(r.ko.Status.UndeletedMessages),
+		)
+	}
+	return nil, nil
+}
+
+// redriveMessage invokes the function asynchronously with the event of the
+// supplied message, and deletes the message once the event was accepted. It
+// returns whether the event was accepted, so that a message that couldn't be
+// deleted afterwards isn't redriven again.
+func (rm *resourceManager) redriveMessage(
+	ctx context.Context,
+	client *sqs.Client,
+	ko *svcapitypes.Redrive,
+	msg sqstypes.Message,
+) (invoked bool, err error) {
+	input, err := rm.newCreateRequestPayload(ctx, &resource{ko})
+	if err != nil {
+		return false, err
+	}
+	input.InvocationType = svcsdktypes.InvocationTypeEvent
+	input.Payload = redrivePayload(aws.ToString(msg.Body))
+
+	var resp *svcsdk.InvokeOutput
+	resp, err = rm.sdkapi.Invoke(ctx, input)
+	rm.metrics.RecordAPICall("CREATE", "Invoke", err)
+	if err != nil {
+		return false, err
+	}
+	setInvokeOutput(ko, resp)
+
+	_, err = client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      ko.Status.QueueURL,
+		ReceiptHandle: msg.ReceiptHandle,
+	})
+	rm.metrics.RecordAPICall("DELETE", "DeleteMessage", err)
+	if err != nil {
+		return true, fmt.Errorf("event redriven but the message couldn't be deleted: %w", err)
+	}
+	return true, nil
+}
+
+// hideMessage hides a message that couldn't be redriven, or whose event was
+// redriven but couldn't be deleted, so that the redrive moves on to the next
+// messages and completes without redriving it again.
+func (rm *resourceManager) hideMessage(
+	ctx context.Context,
+	client *sqs.Client,
+	ko *svcapitypes.Redrive,
+	msg sqstypes.Message,
+) error {
+	_, err := client.ChangeMessageVisibility(ctx, &sqs.ChangeMessageVisibilityInput{
+		QueueUrl:          ko.Status.QueueURL,
+		ReceiptHandle:     msg.ReceiptHandle,
+		VisibilityTimeout: failedVisibilityTimeout,
+	})
+	rm.metrics.RecordAPICall("UPDATE", "ChangeMessageVisibility", err)
+	return err
+}
+
+// setRedriveStarted looks up the URL of the queue and initializes the
+// progress of the redrive. It also clears the output of the dry run done
+// when creating the redrive.
+func (rm *resourceManager) setRedriveStarted(
+	ctx context.Context,
+	ko *svcapitypes.Redrive,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.setRedriveStarted")
+	defer func() { exit(err) }()
+
+	queueARN, err := arn.Parse(*ko.Spec.QueueARN)
+	if err != nil {
+		return err
+	}
+	client, err := rm.sqsClient(*ko.Spec.QueueARN)
+	if err != nil {
+		return err
+	}
+	var resp *sqs.GetQueueUrlOutput
+	resp, err = client.GetQueueUrl(ctx, &sqs.GetQueueUrlInput{
+		QueueName:              aws.String(queueARN.Resource),
+		QueueOwnerAWSAccountId: aws.String(queueARN.AccountID),
+	})
+	rm.metrics.RecordAPICall("READ_ONE", "GetQueueUrl", err)
+	if err != nil {
+		return err
+	}
+
+	ko.Status.ExecutedVersion = nil
+	ko.Status.FunctionError = nil
+	ko.Status.LogResult = nil
+	ko.Status.StatusCode = nil
+	ko.Status.QueueURL = resp.QueueUrl
+	ko.Status.RedrivenMessages = aws.Int64(0)
+	ko.Status.FailedMessages = aws.Int64(0)
+	ko.Status.UndeletedMessages = aws.Int64(0)
+	ko.Status.State = aws.String(stateInProgress)
+	return rm.setRemainingMessages(ctx, client, ko)
+}
+
+// setRemainingMessages records the approximate number of messages left in
+// the queue.
+func (rm *resourceManager) setRemainingMessages(
+	ctx context.Context,
+	client *sqs.Client,
+	ko *svcapitypes.Redrive,
+) error {
+	resp, err := client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl: ko.Status.QueueURL,
+		AttributeNames: []sqstypes.QueueAttributeName{
+			sqstypes.QueueAttributeNameApproximateNumberOfMessages,
+		},
+	})
+	rm.metrics.RecordAPICall("READ_ONE", "GetQueueAttributes", err)
+	if err != nil {
+		return err
+	}
+	remaining, err := strconv.ParseInt(resp.Attributes[string(sqstypes.QueueAttributeNameApproximateNumberOfMessages)], 10, 64)
+	if err != nil {
+		return err
+	}
+	ko.Status.RemainingMessages = &remaining
+	return nil
+}
+
+// sqsClient returns an SQS client for the region of the supplied queue.
+func (rm *resourceManager) sqsClient(queueARN string) (*sqs.Client, error) {
+	parsed, err := arn.Parse(queueARN)
+	if err != nil {
+		return nil, err
+	}
+	return sqs.NewFromConfig(rm.clientcfg, func(o *sqs.Options) {
+		o.Region = parsed.Region
+	}), nil
+}
+
+// setInvokeOutput records the output of the last redriven event in the
+// status.
+func setInvokeOutput(ko *svcapitypes.Redrive, resp *svcsdk.InvokeOutput) {
+	ko.Status.ExecutedVersion = resp.ExecutedVersion
+	ko.Status.FunctionError = resp.FunctionError
+	ko.Status.LogResult = resp.LogResult
+	statusCode := int64(resp.StatusCode)
+	ko.Status.StatusCode = &statusCode
+}
+
+// redrivePayload returns the event of the supplied message body. Dead-letter
+// queues receive the event itself, while on-failure destinations receive an
+// invocation record which holds the event in its requestPayload field.
+func redrivePayload(body string) []byte {
+	var record struct {
+		RequestContext json.RawMessage `json:"requestContext"`
+		RequestPayload json.RawMessage `json:"requestPayload"`
+	}
+	if err := json.Unmarshal([]byte(body), &record); err == nil &&
+		record.RequestContext != nil && record.RequestPayload != nil {
+		return record.RequestPayload
+	}
+	return []byte(body)
+}
+
+// validateRedrive returns an error if the queue, the invocation type, the
+// batch size or the rate of the supplied Redrive is invalid.
+func validateRedrive(ko *svcapitypes.Redrive) error {
+	if ko.Spec.QueueARN == nil {
+		return ErrQueueRequired
+	}
+	queueARN, err := arn.Parse(*ko.Spec.QueueARN)
+	if err != nil {
+		return err
+	}
+	if queueARN.Service != "sqs" {
+		return fmt.Errorf("%q isn't the ARN of an SQS queue", *ko.Spec.QueueARN)
+	}
+	if ko.Spec.InvocationType != nil && *ko.Spec.InvocationType != string(svcsdktypes.InvocationTypeEvent) {
+		return fmt.Errorf("unsupported invocation type %q, redriven events are invoked asynchronously", *ko.Spec.InvocationType)
+	}
+	if ko.Spec.BatchSize != nil && (*ko.Spec.BatchSize < 1 || *ko.Spec.BatchSize > maxBatchSize) {
+		return fmt.Errorf("batch size must be between 1 and %d", maxBatchSize)
+	}
+	if ko.Spec.MessagesPerSecond != nil && *ko.Spec.MessagesPerSecond < 1 {
+		return errors.New("messages per second must be at least 1")
+	}
+	return nil
+}
+
+// redriveCompleted returns true once the queue was drained.
+func redriveCompleted(ko *svcapitypes.Redrive) bool {
+	return aws.ToString(ko.Status.State) == stateCompleted
+}
+
+// batchSize returns the maximum number of messages read from the queue at
+// once.
+func batchSize(ko *svcapitypes.Redrive) int64 {
+	if ko.Spec.BatchSize == nil {
+		return defaultBatchSize
+	}
+	return *ko.Spec.BatchSize
+}
+
+// messagesPerSecond returns the maximum number of messages redriven per
+// second.
+func messagesPerSecond(ko *svcapitypes.Redrive) int64 {
+	if ko.Spec.MessagesPerSecond == nil {
+		return defaultMessagesPerSecond
+	}
+	return *ko.Spec.MessagesPerSecond
+}
+
+// resolveReferenceForQueue reads the Queue referenced from the QueueRef field
+// and sets the QueueARN from its ARN.
+func (rm *resourceManager) resolveReferenceForQueue(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.Redrive,
+) (hasReferences bool, err error) {
+	if ko.Spec.QueueRef == nil || ko.Spec.QueueRef.From == nil {
+		return false, nil
+	}
+	hasReferences = true
+	if ko.Spec.QueueARN != nil {
+		return hasReferences, ackerr.ResourceReferenceAndIDNotSupportedFor("QueueARN", "QueueRef")
+	}
+	arr := ko.Spec.QueueRef.From
+	if arr.Name == nil || *arr.Name == "" {
+		return hasReferences, fmt.Errorf("provided resource reference is nil or empty: QueueRef")
+	}
+	value, err := reference.ResolveUnstructured(
+		ctx,
+		apiReader,
+		rm.cfg.EnableCrossNamespace,
+		&ko.Status.Conditions,
+		ko.ObjectMeta.GetNamespace(),
+		arr,
+		queueGVK,
+		"status", "ackResourceMetadata", "arn",
+	)
+	if err != nil {
+		return hasReferences, err
+	}
+	ko.Spec.QueueARN = &value
+	return hasReferences, nil
+}
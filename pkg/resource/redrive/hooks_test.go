@@ -0,0 +1,117 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package redrive
+
+import (
+	"context"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+)
+
+func Test_redrivePayload(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "dead-letter queue event",
+			body: `{"orderId":"1234"}`,
+			want: `{"orderId":"1234"}`,
+		},
+		{
+			name: "on-failure destination record",
+			body: `{"version":"1.0","requestContext":{"requestId":"abc","condition":"RetriesExhausted"},"requestPayload":{"orderId":"1234"},"responseContext":{"statusCode":200}}`,
+			want: `{"orderId":"1234"}`,
+		},
+		{
+			name: "event with a requestPayload field only",
+			body: `{"requestPayload":{"orderId":"1234"}}`,
+			want: `{"requestPayload":{"orderId":"1234"}}`,
+		},
+		{
+			name: "not JSON",
+			body: `order 1234`,
+			want: `order 1234`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(redrivePayload(tt.body)); got != tt.want {
+				t.Errorf("redrivePayload() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ClearResolvedReferences_queue(t *testing.T) {
+	rm := &resourceManager{}
+	r := &resource{ko: &svcapitypes.Redrive{
+		Spec: svcapitypes.RedriveSpec{
+			QueueARN: aws.String("arn:aws:sqs:us-west-2:123456789012:my-function-dlq"),
+			QueueRef: &ackv1alpha1.AWSResourceReferenceWrapper{
+				From: &ackv1alpha1.AWSResourceReference{Name: aws.String("my-function-dlq")},
+			},
+		},
+	}}
+	cleared := rm.ClearResolvedReferences(r).(*resource)
+	if cleared.ko.Spec.QueueARN != nil {
+		t.Errorf("expected the resolved QueueARN to be cleared, got %q", *cleared.ko.Spec.QueueARN)
+	}
+}
+
+func Test_customDeleteRedrive(t *testing.T) {
+	// The redrive is deleted without any call to Lambda or SQS, the hidden
+	// messages are left in the queue.
+	rm := &resourceManager{}
+	r := &resource{ko: &svcapitypes.Redrive{}}
+	r.ko.Status.State = aws.String(stateInProgress)
+	r.ko.Status.FailedMessages = aws.Int64(2)
+	r.ko.Status.UndeletedMessages = aws.Int64(1)
+	latest, err := rm.customDeleteRedrive(context.TODO(), r)
+	if err != nil {
+		t.Fatalf("customDeleteRedrive() error = %v", err)
+	}
+	if latest != nil {
+		t.Errorf("customDeleteRedrive() = %v, want nil", latest)
+	}
+}
+
+func Test_customPreCompare(t *testing.T) {
+	tests := []struct {
+		name  string
+		state string
+		want  bool
+	}{
+		{name: "in progress", state: stateInProgress, want: true},
+		{name: "completed", state: stateCompleted, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired := &resource{ko: &svcapitypes.Redrive{}}
+			latest := &resource{ko: &svcapitypes.Redrive{}}
+			latest.ko.Status.State = aws.String(tt.state)
+			delta := ackcompare.NewDelta()
+			customPreCompare(delta, desired, latest)
+			if got := delta.DifferentAt("Spec"); got != tt.want {
+				t.Errorf("DifferentAt(Spec) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
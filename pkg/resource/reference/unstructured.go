@@ -0,0 +1,88 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package reference
+
+import (
+	"context"
+	"strings"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrt "github.com/aws-controllers-k8s/runtime/pkg/runtime"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ResolveUnstructured reads the resource of the given kind referenced by ref
+// and returns the string found at fieldPath, such as
+// "status", "ackResourceMetadata", "arn". The resource is read as an
+// unstructured object, so that resources of other ACK controllers are
+// resolved without depending on their API types.
+//
+// The referenced resource must be synced and must not be terminal. The
+// namespace of the reference is resolved against the owner's namespace, and
+// cross-namespace references are recorded in the owner's conditions.
+func ResolveUnstructured(
+	ctx context.Context,
+	apiReader client.Reader,
+	enableCrossNamespace bool,
+	conditions *[]*ackv1alpha1.Condition,
+	ownerNamespace string,
+	ref *ackv1alpha1.AWSResourceReference,
+	gvk schema.GroupVersionKind,
+	fieldPath ...string,
+) (string, error) {
+	namespace, err := ackrt.ResolveCrossNamespaceReference(
+		ctx,
+		enableCrossNamespace,
+		conditions,
+		ackrt.CrossNamespaceRefKindResource,
+		ownerNamespace,
+		ref.Namespace,
+		*ref.Name,
+	)
+	if err != nil {
+		return "", err
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	if err := apiReader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: *ref.Name}, obj); err != nil {
+		return "", err
+	}
+	objConditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	var synced bool
+	for _, c := range objConditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok || cond["status"] != string(corev1.ConditionTrue) {
+			continue
+		}
+		switch cond["type"] {
+		case string(ackv1alpha1.ConditionTypeTerminal):
+			return "", ackerr.ResourceReferenceTerminalFor(gvk.Kind, namespace, *ref.Name)
+		case string(ackv1alpha1.ConditionTypeResourceSynced):
+			synced = true
+		}
+	}
+	if !synced {
+		return "", ackerr.ResourceReferenceNotSyncedFor(gvk.Kind, namespace, *ref.Name)
+	}
+	value, _, _ := unstructured.NestedString(obj.Object, fieldPath...)
+	if value == "" {
+		return "", ackerr.ResourceReferenceMissingTargetFieldFor(gvk.Kind, namespace, *ref.Name, strings.Join(fieldPath, "."))
+	}
+	return value, nil
+}
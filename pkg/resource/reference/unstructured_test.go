@@ -0,0 +1,88 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package reference
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var queueGVK = schema.GroupVersionKind{
+	Group:   "sqs.services.k8s.aws",
+	Version: "v1alpha1",
+	Kind:    "Queue",
+}
+
+func newQueue(name string, conditionType string, arn string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": name, "namespace": "apps"},
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": conditionType, "status": "True"},
+			},
+		},
+	}}
+	obj.SetGroupVersionKind(queueGVK)
+	if arn != "" {
+		_ = unstructured.SetNestedField(obj.Object, arn, "status", "ackResourceMetadata", "arn")
+	}
+	return obj
+}
+
+func TestResolveUnstructured(t *testing.T) {
+	apiReader := fake.NewClientBuilder().WithObjects(
+		newQueue("synced", string(ackv1alpha1.ConditionTypeResourceSynced), "arn:aws:sqs:us-west-2:123456789012:synced"),
+		newQueue("terminal", string(ackv1alpha1.ConditionTypeTerminal), ""),
+		newQueue("no-arn", string(ackv1alpha1.ConditionTypeResourceSynced), ""),
+	).Build()
+	tests := []struct {
+		name    string
+		ref     string
+		want    string
+		wantErr error
+	}{
+		{name: "synced", ref: "synced", want: "arn:aws:sqs:us-west-2:123456789012:synced"},
+		{name: "terminal", ref: "terminal", wantErr: ackerr.ResourceReferenceTerminal},
+		{name: "missing field", ref: "no-arn", wantErr: ackerr.ResourceReferenceMissingTargetField},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditions := []*ackv1alpha1.Condition{}
+			got, err := ResolveUnstructured(
+				context.Background(),
+				apiReader,
+				false,
+				&conditions,
+				"apps",
+				&ackv1alpha1.AWSResourceReference{Name: aws.String(tt.ref)},
+				queueGVK,
+				"status", "ackResourceMetadata", "arn",
+			)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ResolveUnstructured() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveUnstructured() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,311 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package scheduled_invocation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/lambda"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+)
+
+const (
+	// defaultRunHistoryLimit is the number of completed runs kept in the
+	// status when Spec.RunHistoryLimit isn't set.
+	defaultRunHistoryLimit = 5
+	// runTimeout bounds how long the controller waits for a run, and how
+	// long an untracked run is considered active, slightly over the maximum
+	// duration of a Lambda function.
+	runTimeout = 16 * time.Minute
+)
+
+var (
+	ErrNoNextScheduleTime = errors.New("schedule never fires")
+)
+
+// customFindScheduledInvocation returns the supplied resource once its
+// schedule was registered. Schedules aren't stored by Lambda, they're only
+// recorded in the status.
+func (rm *resourceManager) customFindScheduledInvocation(
+	ctx context.Context,
+	r *resource,
+) (*resource, error) {
+	if r.ko.Status.NextScheduleTime == nil && r.ko.Status.LastScheduleTime == nil {
+		return nil, ackerr.NotFound
+	}
+	return &resource{r.ko.DeepCopy()}, nil
+}
+
+// customPreCompare adds a difference when a run is due, when runs are
+// pending, or when the schedule was changed. The difference is added to the
+// spec, as the runtime only updates resources whose spec differs.
+func customPreCompare(
+	delta *ackcompare.Delta,
+	a *resource,
+	b *resource,
+) {
+	next := b.ko.Status.NextScheduleTime
+	switch {
+	case next == nil, !time.Now().Before(next.Time), len(b.ko.Status.ActiveRuns) > 0:
+		delta.Add("Spec", nil, next)
+		return
+	}
+	schedule, err := parseSchedule(aws.ToString(a.ko.Spec.Schedule), a.ko.Spec.TimeZone)
+	if err != nil {
+		delta.Add("Spec.Schedule", a.ko.Spec.Schedule, b.ko.Spec.Schedule)
+		return
+	}
+	expected, ok := schedule.next(lastScheduleTime(b.ko))
+	if !ok || !expected.Equal(next.Time) {
+		delta.Add("Spec", expected, next)
+	}
+}
+
+// customUpdateScheduledInvocation records the runs that completed since the
+// last reconciliation, and starts the run that is due, according to the
+// concurrency policy.
+func (rm *resourceManager) customUpdateScheduledInvocation(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+	delta *ackcompare.Delta,
+) (updated *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.customUpdateScheduledInvocation")
+	defer exit(err)
+
+	if err = validateSchedule(desired.ko); err != nil {
+		return nil, ackerr.NewTerminalError(err)
+	}
+	schedule, _ := parseSchedule(*desired.ko.Spec.Schedule, desired.ko.Spec.TimeZone)
+
+	ko := desired.ko.DeepCopy()
+	ko.Status = *latest.ko.Status.DeepCopy()
+	now := time.Now()
+
+	completed, active := runs.take(ko.GetUID())
+	for _, r := range completed {
+		if aws.ToString(r.status.State) == string(svcapitypes.ScheduledInvocationRunState_Succeeded) {
+			ko.Status.LastSuccessfulTime = r.status.CompletionTime
+		}
+		if r.resp != nil {
+			setRunOutput(ko, r.resp)
+		}
+		ko.Status.RecentRuns = append(ko.Status.RecentRuns, r.status)
+	}
+	untracked, lost := untrackedRuns(ko.Status.ActiveRuns, completed, active, now)
+	ko.Status.RecentRuns = append(ko.Status.RecentRuns, lost...)
+	ko.Status.ActiveRuns = untracked
+	for _, r := range active {
+		ko.Status.ActiveRuns = append(ko.Status.ActiveRuns, r.status)
+	}
+
+	if due, ok := schedule.prev(now); ok && due.After(lastScheduleTime(ko)) {
+		scheduled := metav1.NewTime(due)
+		ko.Status.LastScheduleTime = &scheduled
+		policy := svcapitypes.ScheduledInvocationConcurrencyPolicy(aws.ToString(ko.Spec.ConcurrencyPolicy))
+		if policy == svcapitypes.ScheduledInvocationConcurrencyPolicy_Forbid && len(ko.Status.ActiveRuns) > 0 {
+			rlog.Info("skipping scheduled run, a previous run is still active", "scheduled_time", due)
+			ko.Status.RecentRuns = append(ko.Status.RecentRuns, &svcapitypes.ScheduledInvocationRun{
+				ScheduledTime: &scheduled,
+				State:         aws.String(string(svcapitypes.ScheduledInvocationRunState_Skipped)),
+				Message:       aws.String("a previous run is still active"),
+			})
+		} else {
+			rm.startRun(ctx, ko, scheduled)
+		}
+	}
+
+	if limit := runHistoryLimit(ko); int64(len(ko.Status.RecentRuns)) > limit {
+		ko.Status.RecentRuns = ko.Status.RecentRuns[int64(len(ko.Status.RecentRuns))-limit:]
+	}
+	setNextScheduleTime(ko, schedule)
+	return &resource{ko}, nil
+}
+
+// customDeleteScheduledInvocation stops waiting for the active runs of the
+// supplied ScheduledInvocation. Lambda doesn't support stopping an
+// invocation, so the functions keep running until they return.
+func (rm *resourceManager) customDeleteScheduledInvocation(
+	ctx context.Context,
+	r *resource,
+) (*resource, error) {
+	runs.cancel(r.ko.GetUID())
+	return nil, nil
+}
+
+// startRun invokes the function in the background for the supplied
+// scheduled time, and records the run as active in the status. The run is
+// recorded as failed right away when the invocation request can't be built.
+//
+// The invocation outlives the reconciliation, so it isn't bound to its
+// context. It's bound to runTimeout instead, and canceled when the
+// ScheduledInvocation is deleted.
+func (rm *resourceManager) startRun(
+	ctx context.Context,
+	ko *svcapitypes.ScheduledInvocation,
+	scheduled metav1.Time,
+) {
+	rlog := ackrtlog.FromContext(ctx)
+	input, err := rm.newCreateRequestPayload(ctx, &resource{ko})
+	if err != nil {
+		now := metav1.Now()
+		ko.Status.RecentRuns = append(ko.Status.RecentRuns, &svcapitypes.ScheduledInvocationRun{
+			ScheduledTime:  &scheduled,
+			CompletionTime: &now,
+			State:          aws.String(string(svcapitypes.ScheduledInvocationRunState_Failed)),
+			Message:        aws.String(err.Error()),
+		})
+		return
+	}
+	if ko.Spec.Payload != nil {
+		input.Payload = []byte(*ko.Spec.Payload)
+	}
+
+	start := metav1.Now()
+	runCtx, cancel := context.WithTimeout(context.Background(), runTimeout)
+	r := &run{
+		status: &svcapitypes.ScheduledInvocationRun{
+			ScheduledTime: &scheduled,
+			StartTime:     &start,
+			State:         aws.String(string(svcapitypes.ScheduledInvocationRunState_Running)),
+		},
+		cancel: cancel,
+	}
+	runs.add(ko.GetUID(), r)
+	ko.Status.ActiveRuns = append(ko.Status.ActiveRuns, r.status.DeepCopy())
+	rlog.Info("starting scheduled run", "scheduled_time", scheduled.Time)
+
+	go func() {
+		defer cancel()
+		resp, err := rm.sdkapi.Invoke(runCtx, input)
+		rm.metrics.RecordAPICall("CREATE", "Invoke", err)
+		if err != nil {
+			rm.log.Info("scheduled run failed",
+				"name", ko.GetName(), "namespace", ko.GetNamespace(), "error", err.Error())
+		}
+		runs.finish(r, resp, err)
+	}()
+}
+
+// validateSchedule returns an error if the schedule, the time zone or the
+// concurrency policy of the supplied ScheduledInvocation is invalid.
+func validateSchedule(ko *svcapitypes.ScheduledInvocation) error {
+	schedule, err := parseSchedule(aws.ToString(ko.Spec.Schedule), ko.Spec.TimeZone)
+	if err != nil {
+		return err
+	}
+	if _, ok := schedule.next(time.Now()); !ok {
+		return ErrNoNextScheduleTime
+	}
+	if ko.Spec.ConcurrencyPolicy != nil {
+		switch svcapitypes.ScheduledInvocationConcurrencyPolicy(*ko.Spec.ConcurrencyPolicy) {
+		case svcapitypes.ScheduledInvocationConcurrencyPolicy_Allow,
+			svcapitypes.ScheduledInvocationConcurrencyPolicy_Forbid:
+		default:
+			return fmt.Errorf("unsupported concurrency policy %q", *ko.Spec.ConcurrencyPolicy)
+		}
+	}
+	return nil
+}
+
+// setScheduleRegistered clears the output of the dry run done when creating
+// the schedule, and sets the first scheduled time.
+func setScheduleRegistered(ko *svcapitypes.ScheduledInvocation) {
+	ko.Status.ExecutedVersion = nil
+	ko.Status.FunctionError = nil
+	ko.Status.LogResult = nil
+	ko.Status.StatusCode = nil
+	schedule, err := parseSchedule(aws.ToString(ko.Spec.Schedule), ko.Spec.TimeZone)
+	if err != nil {
+		return
+	}
+	setNextScheduleTime(ko, schedule)
+}
+
+// setNextScheduleTime sets the next time the schedule fires after now.
+func setNextScheduleTime(ko *svcapitypes.ScheduledInvocation, schedule *cronSchedule) {
+	ko.Status.NextScheduleTime = nil
+	if next, ok := schedule.next(time.Now()); ok {
+		t := metav1.NewTime(next)
+		ko.Status.NextScheduleTime = &t
+	}
+}
+
+// setRunOutput records the output of the last completed run in the status.
+func setRunOutput(ko *svcapitypes.ScheduledInvocation, resp *svcsdk.InvokeOutput) {
+	ko.Status.ExecutedVersion = resp.ExecutedVersion
+	ko.Status.FunctionError = resp.FunctionError
+	ko.Status.LogResult = resp.LogResult
+	statusCode := int64(resp.StatusCode)
+	ko.Status.StatusCode = &statusCode
+}
+
+// untrackedRuns returns the runs recorded as active in the status which
+// aren't tracked by this controller process, for example after a restart or a
+// leader election. They're kept active until they outlive runTimeout, and
+// then returned as lost, as their outcome is unknown.
+func untrackedRuns(
+	recorded []*svcapitypes.ScheduledInvocationRun,
+	completed []*run,
+	active []*run,
+	now time.Time,
+) (untracked []*svcapitypes.ScheduledInvocationRun, lost []*svcapitypes.ScheduledInvocationRun) {
+	tracked := map[int64]bool{}
+	for _, r := range append(completed, active...) {
+		tracked[r.status.ScheduledTime.Unix()] = true
+	}
+	for _, status := range recorded {
+		if status.ScheduledTime != nil && tracked[status.ScheduledTime.Unix()] {
+			continue
+		}
+		if status.StartTime != nil && now.Before(status.StartTime.Add(runTimeout)) {
+			untracked = append(untracked, status)
+			continue
+		}
+		status = status.DeepCopy()
+		completion := metav1.NewTime(now)
+		status.CompletionTime = &completion
+		status.State = aws.String(string(svcapitypes.ScheduledInvocationRunState_Lost))
+		status.Message = aws.String("the controller stopped tracking the run before it completed")
+		lost = append(lost, status)
+	}
+	return untracked, lost
+}
+
+// lastScheduleTime returns the time from which the next run is scheduled:
+// the last scheduled time, or the creation time of the resource.
+func lastScheduleTime(ko *svcapitypes.ScheduledInvocation) time.Time {
+	if ko.Status.LastScheduleTime != nil && ko.Status.LastScheduleTime.After(ko.CreationTimestamp.Time) {
+		return ko.Status.LastScheduleTime.Time
+	}
+	return ko.CreationTimestamp.Time
+}
+
+// runHistoryLimit returns the number of completed runs kept in the status.
+func runHistoryLimit(ko *svcapitypes.ScheduledInvocation) int64 {
+	if ko.Spec.RunHistoryLimit == nil || *ko.Spec.RunHistoryLimit < 0 {
+		return defaultRunHistoryLimit
+	}
+	return *ko.Spec.RunHistoryLimit
+}
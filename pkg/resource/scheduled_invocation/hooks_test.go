@@ -0,0 +1,167 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package scheduled_invocation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+)
+
+func Test_customPreCompare(t *testing.T) {
+	created := metav1.NewTime(time.Now().Add(-90 * time.Minute).Truncate(time.Hour))
+	schedule, _ := parseSchedule("@hourly", nil)
+	expected, _ := schedule.next(created.Time)
+	tests := []struct {
+		name       string
+		next       *metav1.Time
+		activeRuns []*svcapitypes.ScheduledInvocationRun
+		want       bool
+	}{
+		{
+			name: "not registered",
+			want: true,
+		},
+		{
+			name: "run due",
+			next: &metav1.Time{Time: time.Now().Add(-time.Minute)},
+			want: true,
+		},
+		{
+			name:       "active runs",
+			next:       &metav1.Time{Time: time.Now().Add(time.Hour)},
+			activeRuns: []*svcapitypes.ScheduledInvocationRun{{State: aws.String(string(svcapitypes.ScheduledInvocationRunState_Running))}},
+			want:       true,
+		},
+		{
+			name: "schedule changed",
+			next: &metav1.Time{Time: expected.Add(time.Minute)},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired := &resource{ko: &svcapitypes.ScheduledInvocation{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created},
+				Spec:       svcapitypes.ScheduledInvocationSpec{Schedule: aws.String("@hourly")},
+			}}
+			latest := &resource{ko: desired.ko.DeepCopy()}
+			latest.ko.Status.NextScheduleTime = tt.next
+			latest.ko.Status.ActiveRuns = tt.activeRuns
+			delta := ackcompare.NewDelta()
+			customPreCompare(delta, desired, latest)
+			if got := delta.DifferentAt("Spec"); got != tt.want {
+				t.Errorf("DifferentAt(Spec) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func newRunStatus(scheduled time.Time, started time.Time) *svcapitypes.ScheduledInvocationRun {
+	scheduledTime := metav1.NewTime(scheduled)
+	startTime := metav1.NewTime(started)
+	return &svcapitypes.ScheduledInvocationRun{
+		ScheduledTime: &scheduledTime,
+		StartTime:     &startTime,
+		State:         aws.String(string(svcapitypes.ScheduledInvocationRunState_Running)),
+	}
+}
+
+func Test_untrackedRuns(t *testing.T) {
+	now := time.Now()
+	tracked := newRunStatus(now.Add(-2*time.Minute), now.Add(-2*time.Minute))
+	recent := newRunStatus(now.Add(-5*time.Minute), now.Add(-5*time.Minute))
+	stale := newRunStatus(now.Add(-time.Hour), now.Add(-time.Hour))
+
+	untracked, lost := untrackedRuns(
+		[]*svcapitypes.ScheduledInvocationRun{stale, recent, tracked},
+		nil,
+		[]*run{{status: tracked}},
+		now,
+	)
+	if len(untracked) != 1 || untracked[0] != recent {
+		t.Errorf("untrackedRuns() untracked = %v, want the recent run", untracked)
+	}
+	if len(lost) != 1 || !lost[0].ScheduledTime.Equal(stale.ScheduledTime) {
+		t.Fatalf("untrackedRuns() lost = %v, want the stale run", lost)
+	}
+	if got := aws.ToString(lost[0].State); got != string(svcapitypes.ScheduledInvocationRunState_Lost) {
+		t.Errorf("lost run state = %q, want Lost", got)
+	}
+}
+
+func Test_customUpdateScheduledInvocation_forbid(t *testing.T) {
+	now := time.Now()
+	desired := &resource{ko: &svcapitypes.ScheduledInvocation{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:               types.UID("forbid"),
+			CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+		},
+		Spec: svcapitypes.ScheduledInvocationSpec{
+			Schedule:          aws.String("* * * * *"),
+			ConcurrencyPolicy: aws.String(string(svcapitypes.ScheduledInvocationConcurrencyPolicy_Forbid)),
+		},
+	}}
+	latest := &resource{ko: desired.ko.DeepCopy()}
+	// A run started before a restart, which this process doesn't track.
+	latest.ko.Status.ActiveRuns = []*svcapitypes.ScheduledInvocationRun{
+		newRunStatus(now.Add(-10*time.Minute), now.Add(-10*time.Minute)),
+	}
+
+	rm := &resourceManager{}
+	updated, err := rm.customUpdateScheduledInvocation(context.TODO(), desired, latest, ackcompare.NewDelta())
+	if err != nil {
+		t.Fatalf("customUpdateScheduledInvocation() error = %v", err)
+	}
+	status := updated.ko.Status
+	if len(status.ActiveRuns) != 1 {
+		t.Errorf("ActiveRuns = %v, want the untracked run", status.ActiveRuns)
+	}
+	if len(status.RecentRuns) != 1 || aws.ToString(status.RecentRuns[0].State) != string(svcapitypes.ScheduledInvocationRunState_Skipped) {
+		t.Errorf("RecentRuns = %v, want a skipped run", status.RecentRuns)
+	}
+	if status.LastScheduleTime == nil {
+		t.Errorf("LastScheduleTime isn't set")
+	}
+}
+
+func Test_customDeleteScheduledInvocation(t *testing.T) {
+	uid := types.UID("deleted")
+	canceled := false
+	runs.add(uid, &run{
+		status: newRunStatus(time.Now(), time.Now()),
+		cancel: func() { canceled = true },
+	})
+
+	rm := &resourceManager{}
+	_, err := rm.customDeleteScheduledInvocation(context.TODO(), &resource{ko: &svcapitypes.ScheduledInvocation{
+		ObjectMeta: metav1.ObjectMeta{UID: uid},
+	}})
+	if err != nil {
+		t.Fatalf("customDeleteScheduledInvocation() error = %v", err)
+	}
+	if !canceled {
+		t.Errorf("the active run wasn't canceled")
+	}
+	if completed, active := runs.take(uid); len(completed) != 0 || len(active) != 0 {
+		t.Errorf("runs are still tracked: completed = %d, active = %d", len(completed), len(active))
+	}
+}
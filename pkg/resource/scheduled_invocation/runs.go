@@ -0,0 +1,146 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package scheduled_invocation
+
+import (
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/lambda"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/lambda-controller/pkg/resource/invoke"
+)
+
+// maxRunResultLength bounds the length of the function response recorded for
+// each run.
+const maxRunResultLength = 1024
+
+// staleRunRetention bounds how long the outcome of a run is kept when its
+// ScheduledInvocation isn't reconciled anymore, for example once deleted.
+const staleRunRetention = time.Hour
+
+// runs tracks the runs started by this controller process. Runs are only
+// started while reconciling, which only the elected leader does. The runs
+// that are still active are also recorded in the status, so that a new
+// leader, or a restarted controller, knows about them.
+var runs = &runTracker{runs: map[types.UID][]*run{}}
+
+// run is a single invocation started for a scheduled time.
+type run struct {
+	status *svcapitypes.ScheduledInvocationRun
+	resp   *svcsdk.InvokeOutput
+	cancel func()
+	done   bool
+	doneAt time.Time
+}
+
+type runTracker struct {
+	sync.Mutex
+	runs map[types.UID][]*run
+}
+
+// add tracks a run started for the supplied ScheduledInvocation.
+func (t *runTracker) add(uid types.UID, r *run) {
+	t.Lock()
+	defer t.Unlock()
+	t.runs[uid] = append(t.runs[uid], r)
+}
+
+// finish records the outcome of a run.
+func (t *runTracker) finish(r *run, resp *svcsdk.InvokeOutput, err error) {
+	t.Lock()
+	defer t.Unlock()
+	now := metav1.Now()
+	r.done = true
+	r.doneAt = now.Time
+	r.status.CompletionTime = &now
+	if err != nil {
+		r.status.State = aws.String(string(svcapitypes.ScheduledInvocationRunState_Failed))
+		r.status.Message = aws.String(err.Error())
+		return
+	}
+	r.resp = resp
+	statusCode := int64(resp.StatusCode)
+	r.status.StatusCode = &statusCode
+	r.status.FunctionError = resp.FunctionError
+	if requestID, ok := awsmiddleware.GetRequestIDMetadata(resp.ResultMetadata); ok {
+		r.status.RequestID = aws.String(requestID)
+	}
+	if len(resp.Payload) > 0 {
+		r.status.Result = aws.String(invoke.TruncatePayload(resp.Payload, maxRunResultLength))
+	}
+	if resp.FunctionError != nil {
+		r.status.State = aws.String(string(svcapitypes.ScheduledInvocationRunState_Failed))
+		return
+	}
+	r.status.State = aws.String(string(svcapitypes.ScheduledInvocationRunState_Succeeded))
+}
+
+// cancel stops waiting for the runs of the supplied ScheduledInvocation and
+// stops tracking them. Lambda doesn't support stopping an invocation, so the
+// functions keep running until they return.
+func (t *runTracker) cancel(uid types.UID) {
+	t.Lock()
+	defer t.Unlock()
+	for _, r := range t.runs[uid] {
+		if !r.done {
+			r.cancel()
+		}
+	}
+	delete(t.runs, uid)
+}
+
+// take returns copies of the completed and active runs of the supplied
+// ScheduledInvocation, and stops tracking the completed ones. Completed runs
+// of other ScheduledInvocations that weren't taken for a while are dropped.
+func (t *runTracker) take(uid types.UID) (completed []*run, active []*run) {
+	t.Lock()
+	defer t.Unlock()
+	for key, tracked := range t.runs {
+		var kept []*run
+		for _, r := range tracked {
+			switch {
+			case !r.done:
+				kept = append(kept, r)
+				if key == uid {
+					active = append(active, r.copy())
+				}
+			case key == uid:
+				completed = append(completed, r.copy())
+			case time.Since(r.doneAt) < staleRunRetention:
+				kept = append(kept, r)
+			}
+		}
+		if len(kept) == 0 {
+			delete(t.runs, key)
+		} else {
+			t.runs[key] = kept
+		}
+	}
+	return completed, active
+}
+
+func (r *run) copy() *run {
+	return &run{
+		status: r.status.DeepCopy(),
+		resp:   r.resp,
+		done:   r.done,
+		doneAt: r.doneAt,
+	}
+}
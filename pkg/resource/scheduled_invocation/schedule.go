@@ -0,0 +1,222 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package scheduled_invocation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// searchWindow bounds how far the previous and next firing times of a
+// schedule are searched for. It covers schedules firing on February 29th.
+const searchWindow = 5 * 366 * 24 * time.Hour
+
+var (
+	macros = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+	monthNames = map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}
+	weekdayNames = map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}
+)
+
+// cronSchedule is a parsed standard cron expression evaluated in a time
+// zone. Each field holds the set of values it matches, a nil set matches any
+// value.
+type cronSchedule struct {
+	minutes  map[int]bool
+	hours    map[int]bool
+	days     map[int]bool
+	months   map[int]bool
+	weekdays map[int]bool
+	loc      *time.Location
+}
+
+// parseSchedule parses a cron expression with five fields (minute, hour, day
+// of month, month and day of week) or one of the @yearly, @monthly, @weekly,
+// @daily and @hourly macros. The time zone defaults to UTC.
+func parseSchedule(schedule string, timeZone *string) (*cronSchedule, error) {
+	s := &cronSchedule{loc: time.UTC}
+	if timeZone != nil {
+		loc, err := time.LoadLocation(*timeZone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %v", *timeZone, err)
+		}
+		s.loc = loc
+	}
+	expr := strings.TrimSpace(schedule)
+	if macro, ok := macros[expr]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields", schedule)
+	}
+	var err error
+	if s.minutes, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, err
+	}
+	if s.hours, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, err
+	}
+	if s.days, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, err
+	}
+	if s.months, err = parseCronField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, err
+	}
+	if s.weekdays, err = parseCronField(fields[4], 0, 7, weekdayNames); err != nil {
+		return nil, err
+	}
+	// Both 0 and 7 are Sunday.
+	if s.weekdays != nil && s.weekdays[7] {
+		s.weekdays[0] = true
+	}
+	return s, nil
+}
+
+// parseCronField parses a comma separated list of values, ranges and steps.
+// A nil set means the field matches any value.
+func parseCronField(field string, min, max int, names map[string]int) (map[int]bool, error) {
+	if field == "*" {
+		return nil, nil
+	}
+	set := map[int]bool{}
+	for _, part := range strings.Split(field, ",") {
+		rng, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rng = part[:i]
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid cron step %q", part)
+			}
+		}
+		lo, hi := min, max
+		if rng != "*" {
+			bounds := strings.SplitN(rng, "-", 2)
+			var err error
+			if lo, err = parseCronValue(bounds[0], names); err != nil {
+				return nil, err
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = parseCronValue(bounds[1], names); err != nil {
+					return nil, err
+				}
+			} else if step > 1 {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return nil, fmt.Errorf("cron value %q out of range", part)
+		}
+		for v := lo; v <= hi; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
+
+func parseCronValue(s string, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToUpper(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("unsupported cron value %q", s)
+	}
+	return v, nil
+}
+
+func matches(set map[int]bool, v int) bool {
+	return set == nil || set[v]
+}
+
+// matchesDay returns true if the schedule fires on the day of t. As with cron,
+// when both the day of month and the day of week are restricted, matching
+// either is enough.
+func (s *cronSchedule) matchesDay(t time.Time) bool {
+	if !matches(s.months, int(t.Month())) {
+		return false
+	}
+	day, weekday := matches(s.days, t.Day()), matches(s.weekdays, int(t.Weekday()))
+	if s.days != nil && s.weekdays != nil {
+		return day || weekday
+	}
+	return day && weekday
+}
+
+// next returns the first minute strictly after t matched by the schedule.
+func (s *cronSchedule) next(t time.Time) (time.Time, bool) {
+	t = t.In(s.loc).Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(searchWindow)
+	for t.Before(limit) {
+		y, m, d := t.Date()
+		var skip time.Time
+		switch {
+		case !s.matchesDay(t):
+			skip = time.Date(y, m, d+1, 0, 0, 0, 0, s.loc)
+		case !matches(s.hours, t.Hour()):
+			skip = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+		case !matches(s.minutes, t.Minute()):
+			skip = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+		if !skip.After(t) {
+			skip = t.Add(time.Minute)
+		}
+		t = skip
+	}
+	return time.Time{}, false
+}
+
+// prev returns the last minute at or before t matched by the schedule.
+func (s *cronSchedule) prev(t time.Time) (time.Time, bool) {
+	t = t.In(s.loc).Truncate(time.Minute)
+	limit := t.Add(-searchWindow)
+	for !t.Before(limit) {
+		y, m, d := t.Date()
+		var skip time.Time
+		switch {
+		case !s.matchesDay(t):
+			// Jump to the last minute of the previous day.
+			skip = time.Date(y, m, d, 0, 0, 0, 0, s.loc).Add(-time.Minute)
+		case !matches(s.hours, t.Hour()):
+			skip = t.Add(-time.Duration(t.Minute()+1) * time.Minute)
+		case !matches(s.minutes, t.Minute()):
+			skip = t.Add(-time.Minute)
+		default:
+			return t, true
+		}
+		if !skip.Before(t) {
+			skip = t.Add(-time.Minute)
+		}
+		t = skip
+	}
+	return time.Time{}, false
+}
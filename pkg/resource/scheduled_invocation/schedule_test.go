@@ -0,0 +1,98 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package scheduled_invocation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+func Test_cronSchedule(t *testing.T) {
+	from := time.Date(2024, time.February, 28, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		schedule string
+		timeZone *string
+		wantNext time.Time
+		wantPrev time.Time
+		wantErr  bool
+	}{
+		{
+			name:     "every 15 minutes",
+			schedule: "*/15 * * * *",
+			wantNext: time.Date(2024, time.February, 28, 10, 45, 0, 0, time.UTC),
+			wantPrev: time.Date(2024, time.February, 28, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekdays with names",
+			schedule: "0 9 * * MON-FRI",
+			wantNext: time.Date(2024, time.February, 29, 9, 0, 0, 0, time.UTC),
+			wantPrev: time.Date(2024, time.February, 28, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "leap day",
+			schedule: "0 0 29 2 *",
+			wantNext: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+			wantPrev: time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "day of month or day of week",
+			schedule: "0 0 1 * SUN",
+			wantNext: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			wantPrev: time.Date(2024, time.February, 25, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "macro in time zone",
+			schedule: "@daily",
+			timeZone: aws.String("Europe/Paris"),
+			wantNext: time.Date(2024, time.February, 28, 23, 0, 0, 0, time.UTC),
+			wantPrev: time.Date(2024, time.February, 27, 23, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "too few fields",
+			schedule: "0 0 * *",
+			wantErr:  true,
+		},
+		{
+			name:     "out of range",
+			schedule: "60 * * * *",
+			wantErr:  true,
+		},
+		{
+			name:     "unknown time zone",
+			schedule: "@hourly",
+			timeZone: aws.String("Mars/Olympus"),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := parseSchedule(tt.schedule, tt.timeZone)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSchedule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got, ok := schedule.next(from); !ok || !got.Equal(tt.wantNext) {
+				t.Errorf("next() = %v, want %v", got, tt.wantNext)
+			}
+			if got, ok := schedule.prev(from); !ok || !got.Equal(tt.wantPrev) {
+				t.Errorf("prev() = %v, want %v", got, tt.wantPrev)
+			}
+		})
+	}
+}
@@ -127,6 +127,38 @@ func newResourceDelta(
 	if ackcompare.HasNilDifference(a.ko.Spec.ProvisionedConcurrencyConfig, b.ko.Spec.ProvisionedConcurrencyConfig) {
 		delta.Add("Spec.ProvisionedConcurrencyConfig", a.ko.Spec.ProvisionedConcurrencyConfig, b.ko.Spec.ProvisionedConcurrencyConfig)
 	} else if a.ko.Spec.ProvisionedConcurrencyConfig != nil && b.ko.Spec.ProvisionedConcurrencyConfig != nil {
+		if ackcompare.HasNilDifference(a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling) {
+			delta.Add("Spec.ProvisionedConcurrencyConfig.Autoscaling", a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling)
+		} else if a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling != nil && b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling != nil {
+			if ackcompare.HasNilDifference(a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MaxCapacity, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MaxCapacity) {
+				delta.Add("Spec.ProvisionedConcurrencyConfig.Autoscaling.MaxCapacity", a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MaxCapacity, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MaxCapacity)
+			} else if a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MaxCapacity != nil && b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MaxCapacity != nil {
+				if *a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MaxCapacity != *b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MaxCapacity {
+					delta.Add("Spec.ProvisionedConcurrencyConfig.Autoscaling.MaxCapacity", a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MaxCapacity, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MaxCapacity)
+				}
+			}
+			if ackcompare.HasNilDifference(a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MinCapacity, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MinCapacity) {
+				delta.Add("Spec.ProvisionedConcurrencyConfig.Autoscaling.MinCapacity", a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MinCapacity, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MinCapacity)
+			} else if a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MinCapacity != nil && b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MinCapacity != nil {
+				if *a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MinCapacity != *b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MinCapacity {
+					delta.Add("Spec.ProvisionedConcurrencyConfig.Autoscaling.MinCapacity", a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MinCapacity, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.MinCapacity)
+				}
+			}
+			if len(a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.ScheduledActions) != len(b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.ScheduledActions) {
+				delta.Add("Spec.ProvisionedConcurrencyConfig.Autoscaling.ScheduledActions", a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.ScheduledActions, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.ScheduledActions)
+			} else if len(a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.ScheduledActions) > 0 {
+				if !equality.Semantic.Equalities.DeepEqual(a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.ScheduledActions, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.ScheduledActions) {
+					delta.Add("Spec.ProvisionedConcurrencyConfig.Autoscaling.ScheduledActions", a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.ScheduledActions, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.ScheduledActions)
+				}
+			}
+			if ackcompare.HasNilDifference(a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.TargetUtilization, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.TargetUtilization) {
+				delta.Add("Spec.ProvisionedConcurrencyConfig.Autoscaling.TargetUtilization", a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.TargetUtilization, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.TargetUtilization)
+			} else if a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.TargetUtilization != nil && b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.TargetUtilization != nil {
+				if *a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.TargetUtilization != *b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.TargetUtilization {
+					delta.Add("Spec.ProvisionedConcurrencyConfig.Autoscaling.TargetUtilization", a.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.TargetUtilization, b.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling.TargetUtilization)
+				}
+			}
+		}
 		if ackcompare.HasNilDifference(a.ko.Spec.ProvisionedConcurrencyConfig.FunctionName, b.ko.Spec.ProvisionedConcurrencyConfig.FunctionName) {
 			delta.Add("Spec.ProvisionedConcurrencyConfig.FunctionName", a.ko.Spec.ProvisionedConcurrencyConfig.FunctionName, b.ko.Spec.ProvisionedConcurrencyConfig.FunctionName)
 		} else if a.ko.Spec.ProvisionedConcurrencyConfig.FunctionName != nil && b.ko.Spec.ProvisionedConcurrencyConfig.FunctionName != nil {
@@ -16,20 +16,29 @@ package version
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/lambda-controller/pkg/resource/autoscaling"
 	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
 	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
 	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
 	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/service/applicationautoscaling"
 	svcsdk "github.com/aws/aws-sdk-go-v2/service/lambda"
 	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
 	"github.com/aws/aws-sdk-go/aws"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var (
-	ErrFunctionPending = errors.New("function in 'Pending' state, cannot be modified or deleted")
+	ErrFunctionPending          = errors.New("function in 'Pending' state, cannot be modified or deleted")
+	ErrFunctionUpdateInProgress = errors.New("function update in progress, cannot publish a version")
+	ErrFunctionUpdateFailed     = errors.New("last function update failed, cannot publish a version")
+	ErrLatestMismatch           = errors.New("$LATEST does not match the expected code SHA256 or revision ID, cannot publish a version")
+	ErrVersionNotChanged        = errors.New("No changes were made to $LATEST since publishing last version, so no version was published.")
+	ErrVersionIndexNotSetUp     = errors.New("the index of the Version resources isn't set up, cannot look up the managed versions")
 )
 
 var (
@@ -37,8 +46,19 @@ var (
 		ErrFunctionPending,
 		5*time.Second,
 	)
+	requeueWaitWhileFunctionUpdating = ackrequeue.NeededAfter(
+		ErrFunctionUpdateInProgress,
+		5*time.Second,
+	)
 )
 
+// requeueWaitForLatest returns a RequeueNeededAfter error used while $LATEST
+// doesn't match the code or configuration the version must be published from.
+// The referenced Function might still be updated.
+func requeueWaitForLatest(err error) *ackrequeue.RequeueNeededAfter {
+	return ackrequeue.NeededAfter(err, 15*time.Second)
+}
+
 // isVersionPending returns true if the supplied Function Version is in a pending
 // state
 func isVersionPending(r *resource) bool {
@@ -49,6 +69,121 @@ func isVersionPending(r *resource) bool {
 	return state == string(svcapitypes.State_Pending)
 }
 
+// resolveFunctionCodeSHA256 sets the code SHA256 the version is expected to be
+// published from to the one observed by the referenced Function, when neither
+// CodeSHA256 nor RevisionID are set and the version isn't published yet.
+func (rm *resourceManager) resolveFunctionCodeSHA256(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.Version,
+) error {
+	if ko.Status.Version != nil || ko.Spec.CodeSHA256 != nil || ko.Spec.RevisionID != nil {
+		return nil
+	}
+	if ko.Spec.FunctionRef == nil || ko.Spec.FunctionRef.From == nil || ko.Spec.FunctionRef.From.Name == nil {
+		return nil
+	}
+	namespace := ko.ObjectMeta.GetNamespace()
+	if ko.Spec.FunctionRef.From.Namespace != nil && *ko.Spec.FunctionRef.From.Namespace != "" {
+		namespace = *ko.Spec.FunctionRef.From.Namespace
+	}
+	obj := &svcapitypes.Function{}
+	if err := getReferencedResourceState_Function(ctx, apiReader, obj, *ko.Spec.FunctionRef.From.Name, namespace); err != nil {
+		return err
+	}
+	ko.Spec.CodeSHA256 = obj.Status.CodeSHA256
+	return nil
+}
+
+// checkLatestPublishable waits for the last update of the function to finish
+// and verifies that $LATEST matches the desired code SHA256 and revision ID
+// before a version is published.
+func (rm *resourceManager) checkLatestPublishable(
+	ctx context.Context,
+	desired *resource,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.checkLatestPublishable")
+	defer exit(err)
+
+	var resp *svcsdk.GetFunctionConfigurationOutput
+	resp, err = rm.sdkapi.GetFunctionConfiguration(ctx, &svcsdk.GetFunctionConfigurationInput{
+		FunctionName: desired.ko.Spec.FunctionName,
+	})
+	rm.metrics.RecordAPICall("READ_ONE", "GetFunctionConfiguration", err)
+	if err != nil {
+		return err
+	}
+
+	if resp.State == svcsdktypes.StatePending {
+		return requeueWaitWhilePending
+	}
+	switch resp.LastUpdateStatus {
+	case svcsdktypes.LastUpdateStatusInProgress:
+		return requeueWaitWhileFunctionUpdating
+	case svcsdktypes.LastUpdateStatusFailed:
+		return requeueWaitForLatest(fmt.Errorf(
+			"%w: %s", ErrFunctionUpdateFailed, aws.StringValue(resp.LastUpdateStatusReason),
+		))
+	}
+
+	spec := desired.ko.Spec
+	if spec.CodeSHA256 != nil && aws.StringValue(resp.CodeSha256) != *spec.CodeSHA256 {
+		return requeueWaitForLatest(fmt.Errorf(
+			"%w: code SHA256 is %s, expected %s",
+			ErrLatestMismatch, aws.StringValue(resp.CodeSha256), *spec.CodeSHA256,
+		))
+	}
+	if spec.RevisionID != nil && aws.StringValue(resp.RevisionId) != *spec.RevisionID {
+		return requeueWaitForLatest(fmt.Errorf(
+			"%w: revision ID is %s, expected %s",
+			ErrLatestMismatch, aws.StringValue(resp.RevisionId), *spec.RevisionID,
+		))
+	}
+	return nil
+}
+
+// checkExistingVersion verifies that the existing version PublishVersion
+// returned, when no changes were made to $LATEST, matches the desired
+// resource and isn't managed by another Version resource, so that it can be
+// adopted.
+func (rm *resourceManager) checkExistingVersion(
+	ctx context.Context,
+	desired *resource,
+	resp *svcsdk.PublishVersionOutput,
+) error {
+	rlog := ackrtlog.FromContext(ctx)
+	spec := desired.ko.Spec
+
+	if spec.RequireNewVersion != nil && *spec.RequireNewVersion {
+		return ackerr.NewTerminalError(ErrVersionNotChanged)
+	}
+	owner, err := versionOwner(ctx, versionLister, desired.ko, aws.StringValue(resp.FunctionArn))
+	if err != nil {
+		return err
+	}
+	if owner != nil {
+		return ackerr.NewTerminalError(fmt.Errorf(
+			"%w Existing version %s is managed by Version %s/%s.",
+			ErrVersionNotChanged, *resp.Version, owner.Namespace, owner.Name,
+		))
+	}
+	if spec.CodeSHA256 != nil && aws.StringValue(resp.CodeSha256) != *spec.CodeSHA256 {
+		return ackerr.NewTerminalError(fmt.Errorf(
+			"%w Existing version %s has code SHA256 %s, expected %s.",
+			ErrVersionNotChanged, *resp.Version, aws.StringValue(resp.CodeSha256), *spec.CodeSHA256,
+		))
+	}
+	if spec.Description != nil && aws.StringValue(resp.Description) != *spec.Description {
+		return ackerr.NewTerminalError(fmt.Errorf(
+			"%w Existing version %s has description %q, expected %q.",
+			ErrVersionNotChanged, *resp.Version, aws.StringValue(resp.Description), *spec.Description,
+		))
+	}
+	rlog.Info("adopting existing function version", "version", *resp.Version)
+	return nil
+}
+
 func (rm *resourceManager) customUpdateVersion(
 	ctx context.Context,
 	desired *resource,
@@ -72,7 +207,7 @@ func (rm *resourceManager) customUpdateVersion(
 	}
 
 	if delta.DifferentAt("Spec.ProvisionedConcurrencyConfig") {
-		err = rm.updateProvisionedConcurrency(ctx, desired)
+		err = rm.updateProvisionedConcurrency(ctx, desired, latest)
 		if err != nil {
 			return nil, err
 		}
@@ -201,10 +336,13 @@ func (rm *resourceManager) setFunctionEventInvokeConfig(
 }
 
 // updateProvisionedConcurrency calls `PutProvisionedConcurrencyConfig` to update the fields
-// or `DeleteProvisionedConcurrencyConfig` if users removes the fields
+// or `DeleteProvisionedConcurrencyConfig` if users removes the fields. It also
+// registers the provisioned concurrency with Application Auto Scaling when
+// autoscaling is configured.
 func (rm *resourceManager) updateProvisionedConcurrency(
 	ctx context.Context,
 	desired *resource,
+	latest *resource,
 ) error {
 	var err error
 	rlog := ackrtlog.FromContext(ctx)
@@ -215,9 +353,24 @@ func (rm *resourceManager) updateProvisionedConcurrency(
 		return nil
 	}
 
+	resourceID := autoscaling.ResourceID(*desired.ko.Spec.FunctionName, *desired.ko.Status.Version)
+	var latestConfig *svcapitypes.PutProvisionedConcurrencyConfigInput
+	var latestAutoscaling *svcapitypes.ProvisionedConcurrencyAutoscaling
+	if latest != nil && latest.ko.Spec.ProvisionedConcurrencyConfig != nil {
+		latestConfig = latest.ko.Spec.ProvisionedConcurrencyConfig
+		latestAutoscaling = latestConfig.Autoscaling
+	}
+
 	// Check if the user deleted the 'ProvisionedConcurrency' configuration
 	// If yes, delete ProvisionedConcurrencyConfig
-	if desired.ko.Spec.ProvisionedConcurrencyConfig == nil || desired.ko.Spec.ProvisionedConcurrencyConfig.ProvisionedConcurrentExecutions == nil {
+	dconfig := desired.ko.Spec.ProvisionedConcurrencyConfig
+	if dconfig == nil || (dconfig.ProvisionedConcurrentExecutions == nil && dconfig.Autoscaling == nil) {
+		if latestAutoscaling != nil {
+			err = autoscaling.DeregisterProvisionedConcurrencyAutoscaling(ctx, rm.autoscalingClient(), rm.metrics, resourceID)
+			if err != nil {
+				return err
+			}
+		}
 		input_delete := &svcsdk.DeleteProvisionedConcurrencyConfigInput{
 			FunctionName: aws.String(*desired.ko.Spec.FunctionName),
 			Qualifier:    aws.String(*desired.ko.Status.Version),
@@ -230,19 +383,50 @@ func (rm *resourceManager) updateProvisionedConcurrency(
 		return nil
 	}
 
-	dspec := desired.ko.Spec
-	input := &svcsdk.PutProvisionedConcurrencyConfigInput{
-		FunctionName:                    aws.String(*desired.ko.Spec.FunctionName),
-		Qualifier:                       aws.String(*desired.ko.Status.Version),
-		ProvisionedConcurrentExecutions: aws.Int32(int32(*dspec.ProvisionedConcurrencyConfig.ProvisionedConcurrentExecutions)),
+	// With autoscaling the scaler owns the allocated provisioned concurrency,
+	// so only put the initial value when none is allocated yet.
+	executions := dconfig.ProvisionedConcurrentExecutions
+	if executions == nil {
+		executions = dconfig.Autoscaling.MinCapacity
 	}
+	if dconfig.Autoscaling == nil || latestConfig == nil {
+		input := &svcsdk.PutProvisionedConcurrencyConfigInput{
+			FunctionName:                    aws.String(*desired.ko.Spec.FunctionName),
+			Qualifier:                       aws.String(*desired.ko.Status.Version),
+			ProvisionedConcurrentExecutions: aws.Int32(int32(*executions)),
+		}
 
-	_, err = rm.sdkapi.PutProvisionedConcurrencyConfig(ctx, input)
-	rm.metrics.RecordAPICall("UPDATE", "UpdateProvisionedConcurrency", err)
-	if err != nil {
-		return err
+		_, err = rm.sdkapi.PutProvisionedConcurrencyConfig(ctx, input)
+		rm.metrics.RecordAPICall("UPDATE", "UpdateProvisionedConcurrency", err)
+		if err != nil {
+			return err
+		}
 	}
-	return nil
+
+	return autoscaling.SyncProvisionedConcurrencyAutoscaling(
+		ctx, rm.autoscalingClient(), rm.metrics, resourceID,
+		dconfig.Autoscaling, latestAutoscaling,
+	)
+}
+
+// deregisterProvisionedConcurrencyAutoscaling removes the Application Auto
+// Scaling target of the version provisioned concurrency, if any.
+func (rm *resourceManager) deregisterProvisionedConcurrencyAutoscaling(
+	ctx context.Context,
+	r *resource,
+) error {
+	if r.ko.Status.Version == nil || r.ko.Spec.ProvisionedConcurrencyConfig == nil ||
+		r.ko.Spec.ProvisionedConcurrencyConfig.Autoscaling == nil {
+		return nil
+	}
+	return autoscaling.DeregisterProvisionedConcurrencyAutoscaling(
+		ctx, rm.autoscalingClient(), rm.metrics,
+		autoscaling.ResourceID(*r.ko.Spec.FunctionName, *r.ko.Status.Version),
+	)
+}
+
+func (rm *resourceManager) autoscalingClient() *applicationautoscaling.Client {
+	return applicationautoscaling.NewFromConfig(rm.clientcfg)
 }
 
 // setProvisionedConcurrencyConfig sets the Provisioned Concurrency
@@ -268,6 +452,7 @@ func (rm *resourceManager) setProvisionedConcurrencyConfig(
 	if err != nil {
 		if awserr, ok := ackerr.AWSError(err); ok && (awserr.ErrorCode() == "ProvisionedConcurrencyConfigNotFoundException" || awserr.ErrorCode() == "ResourceNotFoundException") {
 			ko.Spec.ProvisionedConcurrencyConfig = nil
+			ko.Status.ProvisionedConcurrency = nil
 		} else {
 			return err
 		}
@@ -275,6 +460,31 @@ func (rm *resourceManager) setProvisionedConcurrencyConfig(
 		// creating ProvisionedConcurrency object to store the values returned from `Get` call
 		cloudProvisionedConcurrency := &svcapitypes.PutProvisionedConcurrencyConfigInput{}
 		cloudProvisionedConcurrency.ProvisionedConcurrentExecutions = aws.Int64(int64(*getProvisionedConcurrencyConfigOutput.RequestedProvisionedConcurrentExecutions))
+		var desiredAutoscaling *svcapitypes.ProvisionedConcurrencyAutoscaling
+		if ko.Spec.ProvisionedConcurrencyConfig != nil {
+			desiredAutoscaling = ko.Spec.ProvisionedConcurrencyConfig.Autoscaling
+		}
+		if autoscaling.InUse(desiredAutoscaling, ko.Status.ProvisionedConcurrency) {
+			cloudProvisionedConcurrency.Autoscaling, err = autoscaling.GetProvisionedConcurrencyAutoscaling(
+				ctx, rm.autoscalingClient(), rm.metrics,
+				autoscaling.ResourceID(*ko.Spec.FunctionName, *ko.Status.Version),
+			)
+			if err != nil {
+				return err
+			}
+		}
+		ko.Status.ProvisionedConcurrency = autoscaling.ProvisionedConcurrencyStatus(cloudProvisionedConcurrency.Autoscaling, time.Now())
+		ko.Status.ProvisionedConcurrency.AllocatedProvisionedConcurrentExecutions = int64OrNil(getProvisionedConcurrencyConfigOutput.AllocatedProvisionedConcurrentExecutions)
+		ko.Status.ProvisionedConcurrency.Status = aws.String(string(getProvisionedConcurrencyConfigOutput.Status))
+		if cloudProvisionedConcurrency.Autoscaling != nil {
+			// The allocated value is owned by the scaler, keep the requested
+			// initial value so that scaling doesn't show up as a difference.
+			cloudProvisionedConcurrency.ProvisionedConcurrentExecutions = nil
+			if ko.Spec.ProvisionedConcurrencyConfig != nil {
+				cloudProvisionedConcurrency.ProvisionedConcurrentExecutions = ko.Spec.ProvisionedConcurrencyConfig.ProvisionedConcurrentExecutions
+				autoscaling.NormalizeObserved(cloudProvisionedConcurrency.Autoscaling, ko.Spec.ProvisionedConcurrencyConfig.Autoscaling)
+			}
+		}
 		ko.Spec.ProvisionedConcurrencyConfig = cloudProvisionedConcurrency
 	}
 
@@ -305,3 +515,10 @@ func (rm *resourceManager) setResourceAdditionalFields(
 
 	return nil
 }
+
+func int64OrNil(val *int32) *int64 {
+	if val != nil {
+		return aws.Int64(int64(*val))
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package version
+
+import (
+	"context"
+
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+)
+
+// functionARNIndex indexes the Version resources by the ARN of the version
+// they manage.
+const functionARNIndex = "status.functionARN"
+
+// versionLister lists the Version resources from the cache of the controller
+// manager. It is set by SetupFunctionARNIndex.
+var versionLister client.Reader
+
+// SetupFunctionARNIndex indexes the Version resources cached by the
+// controller manager by the ARN of the version they manage, so that a
+// version is never adopted by two Version resources. It must be called
+// before the manager is started.
+func SetupFunctionARNIndex(ctx context.Context, mgr ctrlrt.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(
+		ctx,
+		&svcapitypes.Version{},
+		functionARNIndex,
+		indexFunctionARN,
+	)
+	if err != nil {
+		return err
+	}
+	versionLister = mgr.GetClient()
+	return nil
+}
+
+// indexFunctionARN returns the index values of a Version resource for
+// functionARNIndex.
+func indexFunctionARN(obj client.Object) []string {
+	if arn := obj.(*svcapitypes.Version).Status.FunctionARN; arn != nil && *arn != "" {
+		return []string{*arn}
+	}
+	return nil
+}
+
+// versionOwner returns the Version resource, other than the supplied one,
+// that manages the version with the supplied ARN, or nil.
+func versionOwner(
+	ctx context.Context,
+	lister client.Reader,
+	ko *svcapitypes.Version,
+	functionARN string,
+) (*svcapitypes.Version, error) {
+	if lister == nil {
+		return nil, ErrVersionIndexNotSetUp
+	}
+	list := &svcapitypes.VersionList{}
+	if err := lister.List(ctx, list, client.MatchingFields{functionARNIndex: functionARN}); err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		if list.Items[i].UID != ko.UID {
+			return &list.Items[i], nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,72 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package version
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	svcapitypes "github.com/aws-controllers-k8s/lambda-controller/apis/v1alpha1"
+)
+
+const versionARN = "arn:aws:lambda:us-west-2:123456789012:function:my-function:3"
+
+func Test_checkExistingVersion_managedByAnotherVersion(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := svcapitypes.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	versionLister = fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&svcapitypes.Version{}, functionARNIndex, indexFunctionARN).
+		WithObjects(&svcapitypes.Version{
+			ObjectMeta: metav1.ObjectMeta{Name: "v3", Namespace: "apps", UID: types.UID("owner")},
+			Status: svcapitypes.VersionStatus{
+				FunctionARN: aws.String(versionARN),
+				Version:     aws.String("3"),
+			},
+		}).
+		Build()
+	defer func() { versionLister = nil }()
+	resp := &svcsdk.PublishVersionOutput{
+		FunctionArn: aws.String(versionARN),
+		Version:     aws.String("3"),
+	}
+	rm := &resourceManager{}
+
+	desired := &resource{ko: &svcapitypes.Version{
+		ObjectMeta: metav1.ObjectMeta{Name: "v3-copy", Namespace: "apps", UID: types.UID("copy")},
+	}}
+	err := rm.checkExistingVersion(context.TODO(), desired, resp)
+	var terminalErr *ackerr.TerminalError
+	if !errors.As(err, &terminalErr) || !errors.Is(err, ErrVersionNotChanged) {
+		t.Errorf("checkExistingVersion() error = %v, want a terminal %v", err, ErrVersionNotChanged)
+	}
+
+	owner := &resource{ko: &svcapitypes.Version{
+		ObjectMeta: metav1.ObjectMeta{Name: "v3", Namespace: "apps", UID: types.UID("owner")},
+	}}
+	if err := rm.checkExistingVersion(context.TODO(), owner, resp); err != nil {
+		t.Errorf("checkExistingVersion() for the owner error = %v, want nil", err)
+	}
+}
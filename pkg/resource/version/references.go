@@ -66,6 +66,11 @@ func (rm *resourceManager) ResolveReferences(
 	} else {
 		resourceHasReferences = resourceHasReferences || fieldHasReferences
 	}
+	if err == nil {
+		if err := rm.resolveFunctionCodeSHA256(ctx, apiReader, ko); err != nil {
+			return &resource{ko}, resourceHasReferences, err
+		}
+	}
 
 	return &resource{ko}, resourceHasReferences, err
 }
@@ -434,6 +434,9 @@ func (rm *resourceManager) sdkCreate(
 	defer func() {
 		exit(err)
 	}()
+	if err = rm.checkLatestPublishable(ctx, desired); err != nil {
+		return nil, err
+	}
 	var marker *string = nil
 	var versionList []svcsdktypes.FunctionConfiguration
 	for {
@@ -469,8 +472,10 @@ func (rm *resourceManager) sdkCreate(
 	ko := desired.ko.DeepCopy()
 	for _, version := range versionList {
 		if *version.Version == *resp.Version {
-			ErrCannotCreateResource := errors.New("No changes were made to $LATEST since publishing last version, so no version was published.")
-			return nil, ackerr.NewTerminalError(ErrCannotCreateResource)
+			if err = rm.checkExistingVersion(ctx, desired, resp); err != nil {
+				return nil, err
+			}
+			break
 		}
 	}
 
@@ -777,7 +782,7 @@ func (rm *resourceManager) sdkCreate(
 		}
 	}
 	if ko.Spec.ProvisionedConcurrencyConfig != nil {
-		err = rm.updateProvisionedConcurrency(ctx, desired)
+		err = rm.updateProvisionedConcurrency(ctx, desired, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -830,6 +835,10 @@ func (rm *resourceManager) sdkDelete(
 	defer func() {
 		exit(err)
 	}()
+	if err = rm.deregisterProvisionedConcurrencyAutoscaling(ctx, r); err != nil {
+		return nil, err
+	}
+
 	input, err := rm.newDeleteRequestPayload(r)
 	if err != nil {
 		return nil, err
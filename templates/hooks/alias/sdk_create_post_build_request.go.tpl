@@ -0,0 +1,3 @@
+	if desired.ko.Spec.RoutingConfig != nil {
+		input.RoutingConfig = addVersionWeightRefs(input.RoutingConfig, desired.ko.Spec.RoutingConfig)
+	}
@@ -1,3 +1,5 @@
+   setAdditionalVersionWeightRefs(ko, desired.ko.Spec.RoutingConfig)
+
    if ko.Spec.FunctionEventInvokeConfig != nil {
       _, err = rm.syncEventInvokeConfig(ctx,desired)
       if err != nil{
@@ -6,7 +8,7 @@
    }
 
    if ko.Spec.ProvisionedConcurrencyConfig != nil {
-      err = rm.updateProvisionedConcurrency(ctx,desired)
+      err = rm.updateProvisionedConcurrency(ctx,desired,nil)
       if err != nil{
          return nil, err
       }
@@ -0,0 +1,3 @@
+	if err = rm.deregisterProvisionedConcurrencyAutoscaling(ctx, r); err != nil {
+		return nil, err
+	}
@@ -1,3 +1,4 @@
+setAdditionalVersionWeightRefs(ko, r.ko.Spec.RoutingConfig)
 if err := rm.setResourceAdditionalFields(ctx, ko); err != nil {
 		return nil, err
 }
\ No newline at end of file
@@ -0,0 +1,13 @@
+
+    if desired.ko.Spec.RoutingConfig != nil {
+        input.RoutingConfig = addVersionWeightRefs(input.RoutingConfig, desired.ko.Spec.RoutingConfig)
+    }
+    // UpdateAlias leaves the existing routing configuration in place when
+    // RoutingConfig is omitted, so send an empty one to clear the weights.
+    if input.RoutingConfig == nil && latest.ko.Spec.RoutingConfig != nil &&
+        (len(latest.ko.Spec.RoutingConfig.AdditionalVersionWeights) > 0 ||
+            len(latest.ko.Spec.RoutingConfig.AdditionalVersionWeightRefs) > 0) {
+        input.RoutingConfig = &svcsdktypes.AliasRoutingConfiguration{
+            AdditionalVersionWeights: map[string]float64{},
+        }
+    }
@@ -0,0 +1 @@
+	setAdditionalVersionWeightRefs(ko, desired.ko.Spec.RoutingConfig)
@@ -5,7 +5,7 @@
         }
     }
     if delta.DifferentAt("Spec.ProvisionedConcurrencyConfig"){
-        err = rm.updateProvisionedConcurrency(ctx, desired)
+        err = rm.updateProvisionedConcurrency(ctx, desired, latest)
         if err != nil {
             return nil, err
         }
@@ -18,4 +18,11 @@
 	}
     if !delta.DifferentExcept("Spec.ProvisionedConcurrencyConfig", "Spec.FunctionEventInvokeConfig", "Spec.Permissions") {
         return desired, nil
+    }
+    if desired.ko.Spec.DeploymentStrategy != nil && delta.DifferentAt("Spec.FunctionVersion") {
+        var shifted *resource
+        shifted, err = rm.shiftTraffic(ctx, desired, latest)
+        if shifted != nil || err != nil {
+            return shifted, err
+        }
     }
\ No newline at end of file
@@ -0,0 +1,4 @@
+	if err = setFilterPatterns(input.FilterCriteria, desired.ko); err != nil {
+		return nil, ackerr.NewTerminalError(err)
+	}
+	setOwnerTag(input, desired.ko)
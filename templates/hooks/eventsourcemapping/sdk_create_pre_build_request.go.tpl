@@ -0,0 +1,6 @@
+	if err = validateProvisionedPollerConfig(desired.ko); err != nil {
+		return nil, ackerr.NewTerminalError(err)
+	}
+	if err = validateFilterCriteria(desired.ko); err != nil {
+		return nil, ackerr.NewTerminalError(err)
+	}
\ No newline at end of file
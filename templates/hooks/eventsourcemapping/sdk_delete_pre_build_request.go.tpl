@@ -0,0 +1,3 @@
+	if isTransitioning(r) {
+		return r, requeueWaitWhileTransitioning
+	}
\ No newline at end of file
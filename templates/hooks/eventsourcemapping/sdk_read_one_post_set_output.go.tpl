@@ -1,4 +1,9 @@
+	setFilterCriteriaErrorCondition(&resource{ko})
+	setDegradedCondition(&resource{ko})
+	setFilterCriteriaHiddenCondition(r, &resource{ko})
+	restoreFilterRules(r.ko, ko)
 	ko.Spec.Tags, err = rm.getTags(ctx, string(*ko.Status.ACKResourceMetadata.ARN))
 	if err != nil {
 		return nil, err
 	}
+	delete(ko.Spec.Tags, ownerTagKey)
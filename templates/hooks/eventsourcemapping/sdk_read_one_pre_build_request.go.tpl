@@ -0,0 +1,6 @@
+	if r.ko.Status.UUID == nil {
+		r, err = rm.findExistingMapping(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+	}
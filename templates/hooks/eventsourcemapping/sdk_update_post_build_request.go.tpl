@@ -1,4 +1,8 @@
 
+    if err = setFilterPatterns(input.FilterCriteria, desired.ko); err != nil {
+        return nil, ackerr.NewTerminalError(err)
+    }
+
     // We need to carefully craft the update request if a user
     // wants to delete their filterCriterias. Mainly because the
     // aws-sdk-go doesn't try to update nil fields.
@@ -6,4 +10,11 @@
         input.FilterCriteria = &svcsdktypes.FilterCriteria{
             Filters: []svcsdktypes.Filter{},
         }
+    }
+    // Same goes for the metrics configuration: removing the field
+    // from the spec has to be sent as an empty list of metrics.
+    if metricsConfigDeleted(latest, desired, delta) {
+        input.MetricsConfig = &svcsdktypes.EventSourceMappingMetricsConfig{
+            Metrics: []svcsdktypes.EventSourceMappingMetric{},
+        }
     }
\ No newline at end of file
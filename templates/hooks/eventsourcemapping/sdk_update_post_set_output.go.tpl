@@ -0,0 +1,2 @@
+	setFilterCriteriaErrorCondition(&resource{ko})
+	restoreFilterRules(desired.ko, ko)
\ No newline at end of file
@@ -1,3 +1,8 @@
+	if isTransitioning(latest) {
+		updated := rm.concreteResource(desired.DeepCopy())
+		updated.SetStatus(latest)
+		return updated, requeueWaitWhileTransitioning
+	}
 	if delta.DifferentAt("Spec.Tags") {
 		err := rm.syncTags(ctx, desired, latest)
 		if err != nil {
@@ -7,3 +12,9 @@
 	if !delta.DifferentExcept("Spec.Tags") {
 		return desired, nil
 	}
+	if err = validateProvisionedPollerConfig(desired.ko); err != nil {
+		return nil, ackerr.NewTerminalError(err)
+	}
+	if err = validateFilterCriteria(desired.ko); err != nil {
+		return nil, ackerr.NewTerminalError(err)
+	}
@@ -0,0 +1,3 @@
+if desired.ko.Spec.Payload != nil {
+	input.Payload = []byte(*desired.ko.Spec.Payload)
+}
\ No newline at end of file
@@ -0,0 +1 @@
+setInvocationResult(ko, resp)
\ No newline at end of file
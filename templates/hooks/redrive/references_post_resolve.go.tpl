@@ -0,0 +1,7 @@
+if err == nil {
+	if fieldHasReferences, err := rm.resolveReferenceForQueue(ctx, apiReader, ko); err != nil {
+		return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
+	} else {
+		resourceHasReferences = resourceHasReferences || fieldHasReferences
+	}
+}
\ No newline at end of file
@@ -0,0 +1,3 @@
+// Creating the redrive only checks the function can be invoked, messages
+// are redriven on later reconciliations.
+input.InvocationType = svcsdktypes.InvocationTypeDryRun
\ No newline at end of file
@@ -0,0 +1,3 @@
+if err = rm.setRedriveStarted(ctx, ko); err != nil {
+	return nil, err
+}
\ No newline at end of file
@@ -0,0 +1,3 @@
+// Creating the schedule only checks the function can be invoked, runs
+// are started on later reconciliations.
+input.InvocationType = svcsdktypes.InvocationTypeDryRun
\ No newline at end of file
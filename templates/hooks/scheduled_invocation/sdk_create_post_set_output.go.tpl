@@ -0,0 +1 @@
+setScheduleRegistered(ko)
\ No newline at end of file
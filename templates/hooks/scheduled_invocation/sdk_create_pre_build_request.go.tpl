@@ -0,0 +1,3 @@
+if err = validateSchedule(desired.ko); err != nil {
+	return nil, ackerr.NewTerminalError(err)
+}
\ No newline at end of file
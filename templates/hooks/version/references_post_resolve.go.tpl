@@ -0,0 +1,5 @@
+if err == nil {
+	if err := rm.resolveFunctionCodeSHA256(ctx, apiReader, ko); err != nil {
+		return &resource{ko}, resourceHasReferences, err
+	}
+}
\ No newline at end of file
@@ -5,7 +5,7 @@ if ko.Spec.FunctionEventInvokeConfig != nil {
    }
 }
 if ko.Spec.ProvisionedConcurrencyConfig != nil {
-   err = rm.updateProvisionedConcurrency(ctx,desired)
+   err = rm.updateProvisionedConcurrency(ctx,desired,nil)
    if err != nil{
       return nil, err
    }
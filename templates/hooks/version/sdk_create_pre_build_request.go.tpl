@@ -1,3 +1,6 @@
+if err = rm.checkLatestPublishable(ctx, desired); err != nil {
+	return nil, err
+}
 var marker *string = nil
 var versionList []svcsdktypes.FunctionConfiguration
 for {